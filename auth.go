@@ -0,0 +1,184 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// redacted replaces credentials in error messages and logs.
+const redacted = "[REDACTED]"
+
+// maxSecrets bounds how many rotating tokens are remembered for redaction.
+const maxSecrets = 8
+
+// TokenSource supplies bearer tokens that may expire, such as OAuth2 access
+// tokens. Implementations must be safe for concurrent use.
+type TokenSource interface {
+	// Token returns the current token, typically from a cache.
+	Token(ctx context.Context) (string, error)
+
+	// Refresh discards the current token and returns a new one. It is
+	// called after the API rejects a token with 401 Unauthorized.
+	Refresh(ctx context.Context) (string, error)
+}
+
+// WithAPIKey sends key in the X-API-Key header of every request.
+func WithAPIKey(key string) ClientOption {
+	return func(c *Client) error {
+		if key == "" {
+			return errors.New("API key is empty")
+		}
+		transportFor(c).secrets.add(key)
+		c.RequestEditors = append(c.RequestEditors, func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("X-API-Key", key)
+			return nil
+		})
+		return nil
+	}
+}
+
+// WithBearerToken sends token in an Authorization: Bearer header on every
+// request. Use WithTokenSource for tokens that expire.
+func WithBearerToken(token string) ClientOption {
+	return func(c *Client) error {
+		if token == "" {
+			return errors.New("bearer token is empty")
+		}
+		transportFor(c).secrets.add(token)
+		c.RequestEditors = append(c.RequestEditors, func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		})
+		return nil
+	}
+}
+
+// WithTokenSource sends a bearer token obtained from source on every
+// request. When the API answers 401 Unauthorized, the token is refreshed
+// and the request retried once.
+func WithTokenSource(source TokenSource) ClientOption {
+	return func(c *Client) error {
+		if source == nil {
+			return errors.New("token source is nil")
+		}
+		t := transportFor(c)
+		t.tokens = source
+		c.RequestEditors = append(c.RequestEditors, func(ctx context.Context, req *http.Request) error {
+			token, err := source.Token(ctx)
+			if err != nil {
+				return &AuthError{Err: err, msg: t.redact(err.Error())}
+			}
+			t.secrets.addToken(token)
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		})
+		return nil
+	}
+}
+
+// AuthError reports that credentials for a request could not be obtained,
+// such as a TokenSource failing to produce a token or to refresh one the API
+// rejected. The request is not sent again and the local fallback is not used.
+type AuthError struct {
+	Err error
+	// msg is Err's message with known credentials redacted.
+	msg string
+}
+
+func (e *AuthError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	if e.msg != "" {
+		return "uuidify: token source: " + e.msg
+	}
+	return fmt.Sprintf("uuidify: token source: %v", e.Err)
+}
+
+func (e *AuthError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// refreshOnUnauthorized sends req and, if the API rejects its token,
+// refreshes the token and sends req once more. A failed refresh is reported
+// as an *AuthError instead of the 401 response.
+func (t *transport) refreshOnUnauthorized(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	resp, err := send(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	retry, cerr := cloneRequest(req)
+	if cerr != nil {
+		return resp, err
+	}
+	token, rerr := t.tokens.Refresh(req.Context())
+	if rerr == nil && token == "" {
+		rerr = errors.New("refresh returned an empty token")
+	}
+	drainAndClose(resp.Body)
+	if rerr != nil {
+		return nil, &AuthError{Err: rerr, msg: t.redact(rerr.Error())}
+	}
+	t.secrets.addToken(token)
+
+	retry.Header.Set("Authorization", "Bearer "+token)
+	return send(retry)
+}
+
+// redact replaces every known credential in s.
+func (t *transport) redact(s string) string {
+	if t == nil {
+		return s
+	}
+	return t.secrets.redact(s)
+}
+
+// secrets remembers credentials so they can be scrubbed from error messages
+// and logs. Static credentials are kept for the life of the client; tokens
+// from a TokenSource are kept for the most recent maxSecrets only.
+type secrets struct {
+	mu     sync.Mutex
+	static []string
+	tokens []string
+}
+
+func (s *secrets) add(v string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v != "" && !slices.Contains(s.static, v) {
+		s.static = append(s.static, v)
+	}
+}
+
+func (s *secrets) addToken(v string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v == "" || slices.Contains(s.tokens, v) {
+		return
+	}
+	s.tokens = append(s.tokens, v)
+	if len(s.tokens) > maxSecrets {
+		s.tokens = slices.Delete(s.tokens, 0, len(s.tokens)-maxSecrets)
+	}
+}
+
+func (s *secrets) redact(str string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range s.static {
+		str = strings.ReplaceAll(str, v, redacted)
+	}
+	for _, v := range s.tokens {
+		str = strings.ReplaceAll(str, v, redacted)
+	}
+	return str
+}
@@ -0,0 +1,136 @@
+package uuidify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	// maxBatchCount is the largest count the API accepts in a single call.
+	maxBatchCount = 1000
+
+	defaultBatchConcurrency = 4
+)
+
+// UUIDBatchLarge fetches count UUIDs of the given version, splitting the
+// request into chunks of at most 1000 that run with up to concurrency calls
+// in flight. A concurrency of zero or less uses a default of 4.
+//
+// Results keep chunk order. If any chunk fails, the remaining chunks still
+// run and a *BatchError describing every failed chunk is returned together
+// with the identifiers of the chunks that succeeded, still in chunk order.
+// The failed ranges are left out rather than filled, so callers can refetch
+// just the Count identifiers of each ChunkError and append them.
+func (c *Client) UUIDBatchLarge(ctx context.Context, version string, count, concurrency int) ([]string, error) {
+	if !isSupportedUUIDVersion(GetParamsVersion(version)) {
+		return nil, fmt.Errorf("version must be one of v1, v4, v7")
+	}
+	return c.batchLarge(ctx, count, concurrency, func(ctx context.Context, n int) ([]string, error) {
+		return c.UUIDBatch(ctx, version, n)
+	})
+}
+
+// ULIDBatchLarge fetches count ULIDs in chunks of at most 1000. See
+// UUIDBatchLarge for the concurrency and error semantics.
+func (c *Client) ULIDBatchLarge(ctx context.Context, count, concurrency int) ([]string, error) {
+	return c.batchLarge(ctx, count, concurrency, c.ULIDBatch)
+}
+
+func (c *Client) batchLarge(ctx context.Context, count, concurrency int, fetch func(context.Context, int) ([]string, error)) ([]string, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("count must be positive")
+	}
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	chunks := (count + maxBatchCount - 1) / maxBatchCount
+	results := make([][]string, chunks)
+	errs := make([]error, chunks)
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < chunks; i++ {
+		n := min(maxBatchCount, count-i*maxBatchCount)
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = fetch(ctx, n)
+		}()
+	}
+	wg.Wait()
+
+	var batchErr BatchError
+	for i, err := range errs {
+		if err != nil {
+			batchErr.Chunks = append(batchErr.Chunks, ChunkError{
+				Offset: i * maxBatchCount,
+				Count:  min(maxBatchCount, count-i*maxBatchCount),
+				Err:    err,
+			})
+		}
+	}
+	ids := make([]string, 0, count)
+	for _, r := range results {
+		ids = append(ids, r...)
+	}
+	if len(batchErr.Chunks) > 0 {
+		batchErr.Total = chunks
+		return ids, &batchErr
+	}
+	return ids, nil
+}
+
+// ChunkError describes one failed chunk of a large batch request.
+type ChunkError struct {
+	// Offset is the index of the chunk's first identifier in the full batch.
+	Offset int
+	// Count is the number of identifiers the chunk requested.
+	Count int
+	Err   error
+}
+
+// BatchError aggregates the failed chunks of UUIDBatchLarge and
+// ULIDBatchLarge. errors.Is and errors.As inspect every chunk error.
+type BatchError struct {
+	// Total is the number of chunks the request was split into.
+	Total  int
+	Chunks []ChunkError
+}
+
+func (e *BatchError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "uuidify batch error: %d of %d chunks failed", len(e.Chunks), e.Total)
+	for _, ch := range e.Chunks {
+		fmt.Fprintf(&b, "; [%d:%d]: %v", ch.Offset, ch.Offset+ch.Count, ch.Err)
+	}
+	return b.String()
+}
+
+func (e *BatchError) Unwrap() []error {
+	if e == nil {
+		return nil
+	}
+	errs := make([]error, len(e.Chunks))
+	for i, ch := range e.Chunks {
+		errs[i] = ch.Err
+	}
+	return errs
+}
@@ -0,0 +1,80 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestUUIDBatchLarge_Chunks(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := newBatchServer(t, &calls)
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+
+	ids, err := c.UUIDBatchLarge(context.Background(), "v7", 2501, 2)
+	if err != nil {
+		t.Fatalf("UUIDBatchLarge returned error: %v", err)
+	}
+	if len(ids) != 2501 {
+		t.Fatalf("expected 2501 ids, got %d", len(ids))
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("expected 3 calls, got %d", got)
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			t.Fatalf("duplicate id %s", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestULIDBatchLarge_PartialFailure(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ok := newBatchServer(t, &calls)
+	defer ok.Close()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("count") == strconv.Itoa(maxBatchCount) {
+			ok.Config.Handler.ServeHTTP(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+
+	ids, err := c.ULIDBatchLarge(context.Background(), 3500, 0)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected BatchError, got %T", err)
+	}
+	if batchErr.Total != 4 || len(batchErr.Chunks) != 1 {
+		t.Fatalf("unexpected batch error %v", batchErr)
+	}
+	if ch := batchErr.Chunks[0]; ch.Offset != 3000 || ch.Count != 500 {
+		t.Fatalf("unexpected failed chunk %+v", ch)
+	}
+	if len(ids) != 3000 {
+		t.Fatalf("expected the 3000 identifiers of the successful chunks, got %d", len(ids))
+	}
+	if err := validateULIDs(ids); err != nil {
+		t.Fatalf("invalid identifiers: %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected wrapped APIError, got %v", err)
+	}
+}
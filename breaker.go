@@ -0,0 +1,265 @@
+package uuidify
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBreakerFailureThreshold = 5
+	defaultBreakerMinRequests      = 20
+	defaultBreakerWindow           = 10 * time.Second
+	defaultBreakerOpenTimeout      = 30 * time.Second
+	defaultBreakerHalfOpenRequests = 1
+)
+
+// ErrCircuitOpen is returned, wrapped in a *RequestError, for calls rejected
+// by an open circuit breaker without contacting the API.
+var ErrCircuitOpen = errors.New("uuidify: circuit open")
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every call through while counting failures.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects every call with ErrCircuitOpen.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe calls through to
+	// decide whether to close or reopen the circuit.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig configures WithCircuitBreaker. Zero fields fall back
+// to defaults.
+//
+// A call fails when the API cannot be reached or answers with a 5xx status
+// after any configured retries. Other statuses, including calls held back
+// with ErrRateLimited, count as successes, and calls abandoned by their
+// context are not counted at all.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the
+	// circuit. Defaults to 5.
+	FailureThreshold int
+
+	// FailureRate, if set, also opens the circuit once the fraction of failed
+	// calls within Window reaches it. Must be between 0 and 1.
+	FailureRate float64
+	// MinRequests is the number of calls within Window required before
+	// FailureRate is evaluated. Defaults to 20.
+	MinRequests int
+	// Window is the period over which FailureRate is measured. Defaults to
+	// 10s.
+	Window time.Duration
+
+	// OpenTimeout is how long the circuit stays open before probing the API
+	// again. Defaults to 30s.
+	OpenTimeout time.Duration
+	// HalfOpenRequests is the number of probe calls allowed while half-open;
+	// all of them must succeed to close the circuit. Defaults to 1.
+	HalfOpenRequests int
+
+	// OnStateChange, if non-nil, is invoked synchronously on every state
+	// transition.
+	OnStateChange func(from, to CircuitState)
+}
+
+// WithCircuitBreaker stops calling the API while it is failing. Once the
+// circuit opens, calls fail immediately with ErrCircuitOpen instead of
+// waiting for the HTTP timeout; combined with WithLocalFallback they are
+// served locally right away.
+func WithCircuitBreaker(cfg CircuitBreakerConfig) ClientOption {
+	return func(c *Client) error {
+		if cfg.FailureThreshold < 0 || cfg.FailureRate < 0 || cfg.MinRequests < 0 ||
+			cfg.Window < 0 || cfg.OpenTimeout < 0 || cfg.HalfOpenRequests < 0 {
+			return errors.New("circuit breaker values must not be negative")
+		}
+		if cfg.FailureRate > 1 {
+			return errors.New("failure rate must be between 0 and 1")
+		}
+		if cfg.FailureThreshold == 0 {
+			cfg.FailureThreshold = defaultBreakerFailureThreshold
+		}
+		if cfg.MinRequests == 0 {
+			cfg.MinRequests = defaultBreakerMinRequests
+		}
+		if cfg.Window == 0 {
+			cfg.Window = defaultBreakerWindow
+		}
+		if cfg.OpenTimeout == 0 {
+			cfg.OpenTimeout = defaultBreakerOpenTimeout
+		}
+		if cfg.HalfOpenRequests == 0 {
+			cfg.HalfOpenRequests = defaultBreakerHalfOpenRequests
+		}
+		transportFor(c).breaker = &circuitBreaker{cfg: cfg, now: time.Now}
+		return nil
+	}
+}
+
+type outcome int
+
+const (
+	outcomeSuccess outcome = iota
+	outcomeFailure
+	outcomeIgnored
+)
+
+type transition struct{ from, to CircuitState }
+
+type circuitBreaker struct {
+	cfg CircuitBreakerConfig
+	now func() time.Time
+
+	mu          sync.Mutex
+	state       CircuitState
+	generation  uint64
+	openedAt    time.Time
+	consecutive int
+	windowStart time.Time
+	calls       int
+	failures    int
+	probes      int
+	successes   int
+}
+
+// do sends req unless the circuit is open and records the outcome.
+func (b *circuitBreaker) do(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	gen, err := b.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := send(req)
+	var authErr *AuthError
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		b.record(gen, outcomeIgnored)
+	case errors.As(err, &authErr), errors.Is(err, ErrRateLimited):
+		// The API answered; the credentials or the quota are at fault.
+		b.record(gen, outcomeSuccess)
+	case err != nil || resp.StatusCode >= http.StatusInternalServerError:
+		b.record(gen, outcomeFailure)
+	default:
+		b.record(gen, outcomeSuccess)
+	}
+	return resp, err
+}
+
+// allow reports whether a call may proceed and returns the generation it
+// belongs to, so that outcomes of calls started in an earlier state are
+// discarded.
+func (b *circuitBreaker) allow() (uint64, error) {
+	b.mu.Lock()
+	var changes []transition
+	defer func() {
+		b.mu.Unlock()
+		b.notify(changes)
+	}()
+
+	if b.state == CircuitOpen {
+		if b.now().Sub(b.openedAt) < b.cfg.OpenTimeout {
+			return 0, ErrCircuitOpen
+		}
+		changes = append(changes, b.setState(CircuitHalfOpen))
+	}
+	if b.state == CircuitHalfOpen {
+		if b.probes >= b.cfg.HalfOpenRequests {
+			return 0, ErrCircuitOpen
+		}
+		b.probes++
+	}
+	return b.generation, nil
+}
+
+func (b *circuitBreaker) record(gen uint64, o outcome) {
+	b.mu.Lock()
+	var changes []transition
+	defer func() {
+		b.mu.Unlock()
+		b.notify(changes)
+	}()
+
+	if gen != b.generation {
+		return
+	}
+
+	switch b.state {
+	case CircuitHalfOpen:
+		b.probes--
+		switch o {
+		case outcomeFailure:
+			changes = append(changes, b.setState(CircuitOpen))
+		case outcomeSuccess:
+			b.successes++
+			if b.successes >= b.cfg.HalfOpenRequests {
+				changes = append(changes, b.setState(CircuitClosed))
+			}
+		}
+
+	case CircuitClosed:
+		if o == outcomeIgnored {
+			return
+		}
+		now := b.now()
+		if now.Sub(b.windowStart) >= b.cfg.Window {
+			b.windowStart = now
+			b.calls, b.failures = 0, 0
+		}
+		b.calls++
+		if o == outcomeFailure {
+			b.failures++
+			b.consecutive++
+		} else {
+			b.consecutive = 0
+		}
+
+		tripped := b.consecutive >= b.cfg.FailureThreshold
+		if b.cfg.FailureRate > 0 && b.calls >= b.cfg.MinRequests &&
+			float64(b.failures)/float64(b.calls) >= b.cfg.FailureRate {
+			tripped = true
+		}
+		if tripped {
+			changes = append(changes, b.setState(CircuitOpen))
+		}
+	}
+}
+
+// setState moves the breaker to state and resets its counters. b.mu must be
+// held.
+func (b *circuitBreaker) setState(state CircuitState) transition {
+	t := transition{from: b.state, to: state}
+	b.state = state
+	b.generation++
+	b.consecutive, b.calls, b.failures = 0, 0, 0
+	b.probes, b.successes = 0, 0
+	b.windowStart = b.now()
+	if state == CircuitOpen {
+		b.openedAt = b.windowStart
+	}
+	return t
+}
+
+func (b *circuitBreaker) notify(changes []transition) {
+	if b.cfg.OnStateChange == nil {
+		return
+	}
+	for _, t := range changes {
+		b.cfg.OnStateChange(t.from, t.to)
+	}
+}
@@ -0,0 +1,212 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+	var calls atomic.Int32
+	ts := newBreakerServer(&failing, &calls)
+	defer ts.Close()
+
+	var mu sync.Mutex
+	var changes []string
+	now := time.Now()
+	c, b := newBreakerClient(t, ts, CircuitBreakerConfig{
+		FailureThreshold: 3,
+		OpenTimeout:      time.Minute,
+		OnStateChange: func(from, to CircuitState) {
+			mu.Lock()
+			defer mu.Unlock()
+			changes = append(changes, from.String()+"->"+to.String())
+		},
+	})
+	b.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	failing.Store(true)
+	for i := 0; i < 3; i++ {
+		var apiErr *APIError
+		if _, err := c.UUIDv4(ctx); !errors.As(err, &apiErr) {
+			t.Fatalf("expected APIError, got %v", err)
+		}
+	}
+
+	_, err := c.UUIDv4(ctx)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected RequestError, got %T", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("expected 3 calls to reach the server, got %d", got)
+	}
+
+	// A failed probe reopens the circuit.
+	now = now.Add(time.Minute)
+	if _, err := c.UUIDv4(ctx); errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected probe to reach the server, got %v", err)
+	}
+	if _, err := c.UUIDv4(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen after failed probe, got %v", err)
+	}
+
+	// A successful probe closes it.
+	failing.Store(false)
+	now = now.Add(time.Minute)
+	for i := 0; i < 2; i++ {
+		if _, err := c.UUIDv4(ctx); err != nil {
+			t.Fatalf("UUIDv4 returned error: %v", err)
+		}
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(changes) != len(want) {
+		t.Fatalf("expected transitions %v, got %v", want, changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Fatalf("expected transitions %v, got %v", want, changes)
+		}
+	}
+}
+
+func TestCircuitBreaker_FailureRate(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+	var calls atomic.Int32
+	ts := newBreakerServer(&failing, &calls)
+	defer ts.Close()
+
+	c, _ := newBreakerClient(t, ts, CircuitBreakerConfig{
+		FailureThreshold: 100,
+		FailureRate:      0.5,
+		MinRequests:      4,
+	})
+	ctx := context.Background()
+
+	// Alternating failures never reach the consecutive threshold but do
+	// reach the failure rate.
+	for i := 0; i < 4; i++ {
+		failing.Store(i%2 == 0)
+		if _, err := c.UUIDv4(ctx); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: circuit opened early", i)
+		}
+	}
+	if _, err := c.UUIDv4(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_IgnoresClientErrorsAndCancellation(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("version") == "v7" {
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"Invalid count parameter"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := newBreakerClient(t, ts, CircuitBreakerConfig{FailureThreshold: 1})
+
+	if _, err := c.UUIDv4(context.Background()); errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected 400 APIError, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.UUIDv7(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	var apiErr *APIError
+	if _, err := c.UUIDv4(context.Background()); !errors.As(err, &apiErr) {
+		t.Fatalf("expected circuit to stay closed, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_FallsBackWhileOpen(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+	var calls atomic.Int32
+	ts := newBreakerServer(&failing, &calls)
+	defer ts.Close()
+	failing.Store(true)
+
+	c, err := NewClient(ts.URL,
+		WithHTTPClient(ts.Client()),
+		WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1}),
+		WithLocalFallback(nil),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		res, err := c.GenerateUUIDs(context.Background(), "v4", 1)
+		if err != nil {
+			t.Fatalf("GenerateUUIDs returned error: %v", err)
+		}
+		if !res.Local {
+			t.Fatal("expected local result")
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 call to reach the server, got %d", got)
+	}
+}
+
+func TestWithCircuitBreaker_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, cfg := range []CircuitBreakerConfig{
+		{FailureThreshold: -1},
+		{OpenTimeout: -time.Second},
+		{FailureRate: 1.5},
+	} {
+		if _, err := NewClient("http://example.com", WithCircuitBreaker(cfg)); err == nil {
+			t.Fatalf("expected error for %+v", cfg)
+		}
+	}
+}
+
+func newBreakerServer(failing *atomic.Bool, calls *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":"unavailable"}`))
+			return
+		}
+		w.Write([]byte(`{"uuid":"550e8400-e29b-41d4-a716-446655440000"}`))
+	}))
+}
+
+func newBreakerClient(t *testing.T, ts *httptest.Server, cfg CircuitBreakerConfig) (*Client, *circuitBreaker) {
+	t.Helper()
+	c, err := NewClient(ts.URL, WithHTTPClient(ts.Client()), WithCircuitBreaker(cfg))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return c, lookupTransport(c).breaker
+}
@@ -40,6 +40,10 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// sdk collects the settings of the SDK's own options. NewClient installs
+	// it in front of Client once every option has been applied.
+	sdk *transport
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -65,6 +69,11 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
 	if client.Client == nil {
 		client.Client = &http.Client{}
 	}
+	if client.sdk != nil {
+		if err := client.sdk.install(&client); err != nil {
+			return nil, err
+		}
+	}
 	return &client, nil
 }
 
@@ -0,0 +1,175 @@
+// Command uuidify generates UUIDs and ULIDs through the UUIDify API.
+//
+// Usage:
+//
+//	uuidify <v1|v4|v7|ulid> [flags]
+//
+// Flags:
+//
+//	-count n          number of identifiers to generate (default 1)
+//	-format f         output format: text, json or csv (default text)
+//	-base-url url     API base URL (default https://api.uuidify.io)
+//	-timeout d        per-request timeout (default 5s)
+//	-local            generate in-process when the API is unreachable
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	uuidify "github.com/ilkereroglu/uuidify-go"
+)
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+const usage = `usage: uuidify <v1|v4|v7|ulid> [flags]
+
+Generate UUIDs and ULIDs through the UUIDify API.
+
+Flags:
+`
+
+type options struct {
+	version string
+	count   int
+	format  string
+	baseURL string
+	timeout time.Duration
+	local   bool
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("uuidify", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+
+	var opts options
+	fs.IntVar(&opts.count, "count", 1, "number of identifiers to generate")
+	fs.StringVar(&opts.format, "format", "text", "output format: text, json or csv")
+	fs.StringVar(&opts.baseURL, "base-url", uuidify.DefaultBaseURL, "API base URL")
+	fs.DurationVar(&opts.timeout, "timeout", 5*time.Second, "per-request timeout")
+	fs.BoolVar(&opts.local, "local", false, "generate in-process when the API is unreachable")
+
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		fs.Usage()
+		return 2
+	}
+	opts.version = args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "uuidify: unexpected arguments: %v\n", fs.Args())
+		return 2
+	}
+
+	if err := validate(opts); err != nil {
+		fmt.Fprintf(stderr, "uuidify: %v\n", err)
+		return 2
+	}
+
+	ids, err := generate(ctx, opts, stderr)
+	if err != nil {
+		fmt.Fprintf(stderr, "uuidify: %v\n", err)
+		return 1
+	}
+
+	if err := write(stdout, opts, ids); err != nil {
+		fmt.Fprintf(stderr, "uuidify: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func validate(opts options) error {
+	switch opts.version {
+	case "v1", "v4", "v7", "ulid":
+	default:
+		return fmt.Errorf("unknown command %q: must be one of v1, v4, v7, ulid", opts.version)
+	}
+	if opts.count <= 0 {
+		return errors.New("count must be positive")
+	}
+	switch opts.format {
+	case "text", "json", "csv":
+	default:
+		return fmt.Errorf("format must be one of text, json, csv")
+	}
+	if opts.timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return nil
+}
+
+func generate(ctx context.Context, opts options, stderr io.Writer) ([]string, error) {
+	clientOpts := []uuidify.ClientOption{
+		uuidify.WithBaseURL(opts.baseURL),
+		uuidify.WithHTTPClient(&http.Client{Timeout: opts.timeout}),
+	}
+	if opts.local {
+		clientOpts = append(clientOpts, uuidify.WithLocalFallback(func(_ context.Context, ev uuidify.FallbackEvent) {
+			fmt.Fprintf(stderr, "uuidify: API unavailable, generated %d identifiers locally: %v\n", ev.Count, ev.Err)
+		}))
+	}
+
+	client, err := uuidify.NewDefaultClient(clientOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.version == "ulid" {
+		return client.ULIDBatchLarge(ctx, opts.count, 0)
+	}
+	return client.UUIDBatchLarge(ctx, opts.version, opts.count, 0)
+}
+
+func write(w io.Writer, opts options, ids []string) error {
+	switch opts.format {
+	case "json":
+		key := "uuid"
+		if opts.version == "ulid" {
+			key = "ulid"
+		}
+		var body map[string]any
+		if len(ids) == 1 {
+			body = map[string]any{key: ids[0]}
+		} else {
+			body = map[string]any{key + "s": ids}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(body)
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"version", "id"}); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := cw.Write([]string{opts.version, id}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		for _, id := range ids {
+			if _, err := fmt.Fprintln(w, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
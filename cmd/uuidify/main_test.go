@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ilkereroglu/uuidify-go/uuidifytest"
+)
+
+func TestRun_Text(t *testing.T) {
+	t.Parallel()
+
+	s := uuidifytest.NewServer()
+	defer s.Close()
+
+	stdout, stderr, code := runCLI(t, "v7", "-count", "3", "-base-url", s.URL)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr)
+	}
+	if lines := strings.Split(strings.TrimSpace(stdout), "\n"); len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", stdout)
+	}
+}
+
+func TestRun_JSON(t *testing.T) {
+	t.Parallel()
+
+	s := uuidifytest.NewServer()
+	defer s.Close()
+
+	stdout, stderr, code := runCLI(t, "ulid", "--format", "json", "--base-url", s.URL)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr)
+	}
+	var body struct {
+		ULID string `json:"ulid"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &body); err != nil || len(body.ULID) != 26 {
+		t.Fatalf("unexpected output %q: %v", stdout, err)
+	}
+}
+
+func TestRun_CSV(t *testing.T) {
+	t.Parallel()
+
+	s := uuidifytest.NewServer()
+	defer s.Close()
+
+	stdout, stderr, code := runCLI(t, "v4", "-count", "2", "-format", "csv", "-base-url", s.URL)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 || lines[0] != "version,id" || !strings.HasPrefix(lines[1], "v4,") {
+		t.Fatalf("unexpected output %q", stdout)
+	}
+}
+
+func TestRun_LocalFallback(t *testing.T) {
+	t.Parallel()
+
+	s := uuidifytest.NewServer()
+	defer s.Close()
+	s.FailNext(1, http.StatusServiceUnavailable)
+
+	stdout, stderr, code := runCLI(t, "v4", "-local", "-base-url", s.URL)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr)
+	}
+	if len(strings.TrimSpace(stdout)) != 36 {
+		t.Fatalf("unexpected output %q", stdout)
+	}
+	if !strings.Contains(stderr, "generated 1 identifiers locally") {
+		t.Fatalf("expected fallback warning, got %q", stderr)
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	t.Parallel()
+
+	if _, _, code := runCLI(t); code != 2 {
+		t.Fatalf("expected exit 2, got %d", code)
+	}
+	if _, stderr, code := runCLI(t, "v9"); code != 2 || !strings.Contains(stderr, "unknown command") {
+		t.Fatalf("expected unknown command error, got %d %q", code, stderr)
+	}
+}
+
+func runCLI(t *testing.T, args ...string) (string, string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+	return stdout.String(), stderr.String(), code
+}
@@ -0,0 +1,196 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultCoalesceWindow = 2 * time.Millisecond
+
+// CoalesceConfig configures a Coalescer. Zero fields fall back to defaults.
+type CoalesceConfig struct {
+	// Window is how long the first pending request waits for others of the
+	// same version to join its batch. Defaults to 2ms.
+	Window time.Duration
+
+	// MaxBatch is the number of pending requests that triggers an immediate
+	// call. Defaults to 1000, the API maximum.
+	MaxBatch int
+}
+
+// Coalescer merges concurrent single-identifier requests of the same version
+// into one count=N API call and fans the results back out to the callers.
+//
+// Each caller's context is honored: a caller whose context is done returns
+// immediately, and the shared API call is only canceled once every caller in
+// its batch has given up. Batch methods are passed through to the client.
+type Coalescer struct {
+	client *Client
+	cfg    CoalesceConfig
+
+	mu      sync.Mutex
+	pending map[GetParamsVersion]*coalesceBatch
+}
+
+var _ Generator = (*Coalescer)(nil)
+
+type coalesceBatch struct {
+	waiters []*coalesceWaiter
+	timer   *time.Timer
+}
+
+type coalesceWaiter struct {
+	ctx context.Context
+	ch  chan coalesceResult
+}
+
+type coalesceResult struct {
+	id  string
+	err error
+}
+
+// NewCoalescer returns a Coalescer issuing its calls through client.
+func NewCoalescer(client *Client, cfg CoalesceConfig) (*Coalescer, error) {
+	if client == nil {
+		return nil, errors.New("client is nil")
+	}
+	if cfg.Window < 0 || cfg.MaxBatch < 0 {
+		return nil, errors.New("coalesce config values must not be negative")
+	}
+	if cfg.Window == 0 {
+		cfg.Window = defaultCoalesceWindow
+	}
+	if cfg.MaxBatch == 0 || cfg.MaxBatch > maxBatchCount {
+		cfg.MaxBatch = maxBatchCount
+	}
+	return &Coalescer{
+		client:  client,
+		cfg:     cfg,
+		pending: make(map[GetParamsVersion]*coalesceBatch),
+	}, nil
+}
+
+// UUIDv1 fetches a UUID v1 value, sharing an API call with concurrent callers.
+func (c *Coalescer) UUIDv1(ctx context.Context) (string, error) {
+	return c.get(ctx, GetParamsVersionV1)
+}
+
+// UUIDv4 fetches a UUID v4 value, sharing an API call with concurrent callers.
+func (c *Coalescer) UUIDv4(ctx context.Context) (string, error) {
+	return c.get(ctx, GetParamsVersionV4)
+}
+
+// UUIDv7 fetches a UUID v7 value, sharing an API call with concurrent callers.
+func (c *Coalescer) UUIDv7(ctx context.Context) (string, error) {
+	return c.get(ctx, GetParamsVersionV7)
+}
+
+// ULID fetches a ULID value, sharing an API call with concurrent callers.
+func (c *Coalescer) ULID(ctx context.Context) (string, error) {
+	return c.get(ctx, GetParamsVersionUlid)
+}
+
+// UUIDBatch fetches multiple UUIDs directly through the client.
+func (c *Coalescer) UUIDBatch(ctx context.Context, version string, count int) ([]string, error) {
+	return c.client.UUIDBatch(ctx, version, count)
+}
+
+// ULIDBatch fetches multiple ULIDs directly through the client.
+func (c *Coalescer) ULIDBatch(ctx context.Context, count int) ([]string, error) {
+	return c.client.ULIDBatch(ctx, count)
+}
+
+func (c *Coalescer) get(ctx context.Context, version GetParamsVersion) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	w := &coalesceWaiter{ctx: ctx, ch: make(chan coalesceResult, 1)}
+
+	c.mu.Lock()
+	b := c.pending[version]
+	if b == nil {
+		b = &coalesceBatch{}
+		c.pending[version] = b
+		b.timer = time.AfterFunc(c.cfg.Window, func() { c.flush(version, b) })
+	}
+	b.waiters = append(b.waiters, w)
+	var full []*coalesceWaiter
+	if len(b.waiters) >= c.cfg.MaxBatch {
+		full = c.detach(version, b)
+	}
+	c.mu.Unlock()
+
+	if full != nil {
+		go c.serve(version, full)
+	}
+
+	select {
+	case res := <-w.ch:
+		return res.id, res.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// flush serves b once its window has elapsed, unless it already filled up.
+func (c *Coalescer) flush(version GetParamsVersion, b *coalesceBatch) {
+	c.mu.Lock()
+	waiters := c.detach(version, b)
+	c.mu.Unlock()
+
+	if waiters != nil {
+		c.serve(version, waiters)
+	}
+}
+
+// detach removes b from the pending set and returns its waiters, or nil if
+// b was already detached. c.mu must be held.
+func (c *Coalescer) detach(version GetParamsVersion, b *coalesceBatch) []*coalesceWaiter {
+	if c.pending[version] != b {
+		return nil
+	}
+	delete(c.pending, version)
+	b.timer.Stop()
+	return b.waiters
+}
+
+// serve fetches one identifier per waiter with a single API call, skipping
+// waiters that gave up before the call was made.
+func (c *Coalescer) serve(version GetParamsVersion, waiters []*coalesceWaiter) {
+	// The call inherits values such as trace spans from the first caller and
+	// is canceled once every caller's context is done.
+	waiters = slices.DeleteFunc(waiters, func(w *coalesceWaiter) bool { return w.ctx.Err() != nil })
+	if len(waiters) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(waiters[0].ctx))
+	defer cancel()
+	var live atomic.Int64
+	live.Store(int64(len(waiters)))
+	for _, w := range waiters {
+		stop := context.AfterFunc(w.ctx, func() {
+			if live.Add(-1) == 0 {
+				cancel()
+			}
+		})
+		defer stop()
+	}
+
+	ids, _, err := c.client.fetch(ctx, version, len(waiters))
+	for i, w := range waiters {
+		if err != nil {
+			w.ch <- coalesceResult{err: err}
+			continue
+		}
+		w.ch <- coalesceResult{id: ids[i]}
+	}
+}
@@ -0,0 +1,183 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalescer_MergesConcurrentCalls(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := newBatchServer(t, &calls)
+	defer ts.Close()
+
+	co, err := NewCoalescer(newTestClient(t, ts), CoalesceConfig{Window: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewCoalescer returned error: %v", err)
+	}
+
+	const n = 50
+	ids := make([]string, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids[i], errs[i] = co.UUIDv7(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool, n)
+	for i := range ids {
+		if errs[i] != nil {
+			t.Fatalf("UUIDv7 returned error: %v", errs[i])
+		}
+		if seen[ids[i]] {
+			t.Fatalf("duplicate id %s", ids[i])
+		}
+		seen[ids[i]] = true
+	}
+	if got := calls.Load(); got >= n {
+		t.Fatalf("expected fewer than %d calls, got %d", n, got)
+	}
+}
+
+func TestCoalescer_MaxBatchFlushesEarly(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := newBatchServer(t, &calls)
+	defer ts.Close()
+
+	co, err := NewCoalescer(newTestClient(t, ts), CoalesceConfig{Window: time.Hour, MaxBatch: 2})
+	if err != nil {
+		t.Fatalf("NewCoalescer returned error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := co.ULID(context.Background()); err != nil {
+				t.Errorf("ULID returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 call, got %d", got)
+	}
+}
+
+func TestCoalescer_CallerContext(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	co, err := NewCoalescer(newTestClient(t, ts), CoalesceConfig{Window: time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewCoalescer returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if _, err := co.UUIDv4(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestCoalescer_SkipsCanceledWaiters(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	var mu sync.Mutex
+	var counts []string
+	ok := newBatchServer(t, &calls)
+	defer ok.Close()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		counts = append(counts, r.URL.Query().Get("count"))
+		mu.Unlock()
+		ok.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	co, err := NewCoalescer(newTestClient(t, ts), CoalesceConfig{Window: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewCoalescer returned error: %v", err)
+	}
+	pending := func() int {
+		co.mu.Lock()
+		defer co.mu.Unlock()
+		if b := co.pending[GetParamsVersionV4]; b != nil {
+			return len(b.waiters)
+		}
+		return 0
+	}
+
+	// Every waiter of the batch gives up: no call is made.
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := co.UUIDv4(ctx)
+		done <- err
+	}()
+	for pending() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+
+	// Two of five waiters give up: the call asks for three identifiers.
+	for pending() != 0 {
+		time.Sleep(time.Millisecond)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		waiterCtx := context.Background()
+		if i < 2 {
+			waiterCtx = ctx
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := co.UUIDv4(waiterCtx)
+			if i >= 2 && err != nil {
+				t.Errorf("UUIDv4 returned error: %v", err)
+			}
+		}()
+	}
+	for pending() != 5 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(counts) != 1 || counts[0] != "3" {
+		t.Fatalf("expected a single call for 3 identifiers, got counts %q", counts)
+	}
+}
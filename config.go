@@ -0,0 +1,277 @@
+package uuidify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by LoadConfig.
+const (
+	EnvConfigFile          = "UUIDIFY_CONFIG"
+	EnvBaseURL             = "UUIDIFY_BASE_URL"
+	EnvTimeout             = "UUIDIFY_TIMEOUT"
+	EnvAPIKey              = "UUIDIFY_API_KEY"
+	EnvUserAgent           = "UUIDIFY_USER_AGENT"
+	EnvProxyURL            = "UUIDIFY_PROXY_URL"
+	EnvRetryMaxAttempts    = "UUIDIFY_RETRY_MAX_ATTEMPTS"
+	EnvRetryInitialBackoff = "UUIDIFY_RETRY_INITIAL_BACKOFF"
+	EnvRetryMaxBackoff     = "UUIDIFY_RETRY_MAX_BACKOFF"
+	EnvSource              = "UUIDIFY_SOURCE"
+	EnvSeed                = "UUIDIFY_SEED"
+)
+
+// Config is the client configuration loaded by LoadConfig.
+type Config struct {
+	BaseURL   string
+	Timeout   time.Duration
+	APIKey    string
+	UserAgent string
+
+	// ProxyURL, if set, routes requests through an HTTP, HTTPS or SOCKS5
+	// proxy instead of the one given by the standard proxy variables.
+	ProxyURL string
+
+	// Retry enables retries when non-nil; zero fields use the RetryPolicy
+	// defaults.
+	Retry *RetryPolicy
+
+	// Source selects the Generator built by NewGenerator: SourceRemote,
+	// SourceLocal or SourceSeeded. Empty means SourceRemote.
+	Source string
+	// Seed seeds SourceSeeded.
+	Seed uint64
+}
+
+// fileConfig is the on-disk layout of a configuration file. Durations are
+// strings accepted by time.ParseDuration, such as "2s".
+type fileConfig struct {
+	BaseURL   string `json:"base_url" yaml:"base_url"`
+	Timeout   string `json:"timeout" yaml:"timeout"`
+	APIKey    string `json:"api_key" yaml:"api_key"`
+	UserAgent string `json:"user_agent" yaml:"user_agent"`
+	ProxyURL  string `json:"proxy_url" yaml:"proxy_url"`
+	Retry     *struct {
+		MaxAttempts    int    `json:"max_attempts" yaml:"max_attempts"`
+		InitialBackoff string `json:"initial_backoff" yaml:"initial_backoff"`
+		MaxBackoff     string `json:"max_backoff" yaml:"max_backoff"`
+	} `json:"retry" yaml:"retry"`
+	Source string `json:"source" yaml:"source"`
+	Seed   uint64 `json:"seed" yaml:"seed"`
+}
+
+// LoadConfig builds a Config from, in increasing order of precedence, the
+// defaults of NewDefaultClient, the YAML or JSON file at path and the
+// UUIDIFY_* environment variables. An empty path falls back to
+// $UUIDIFY_CONFIG; if that is unset too, no file is read.
+//
+// A configuration file looks like:
+//
+//	base_url: https://uuidify.internal.example.com
+//	timeout: 2s
+//	api_key: ...
+//	proxy_url: http://egress.example.com:3128
+//	retry:
+//	  max_attempts: 5
+//	  initial_backoff: 200ms
+//	  max_backoff: 3s
+//	source: remote
+//
+// Setting any UUIDIFY_RETRY_* variable enables retries.
+func LoadConfig(path string) (Config, error) {
+	cfg := Config{
+		BaseURL:   DefaultBaseURL,
+		Timeout:   defaultHTTPTimeout,
+		UserAgent: defaultUserAgent,
+	}
+
+	if path == "" {
+		path = os.Getenv(EnvConfigFile)
+	}
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return Config{}, fmt.Errorf("config file %s: %w", path, err)
+		}
+	}
+	if err := cfg.loadEnv(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+func (cfg *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var fc fileConfig
+	switch filepath.Ext(path) {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&fc)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&fc)
+	default:
+		return errors.New("extension must be one of .json, .yaml, .yml")
+	}
+	if err != nil {
+		return err
+	}
+
+	setString(&cfg.BaseURL, fc.BaseURL)
+	setString(&cfg.APIKey, fc.APIKey)
+	setString(&cfg.UserAgent, fc.UserAgent)
+	setString(&cfg.ProxyURL, fc.ProxyURL)
+	setString(&cfg.Source, fc.Source)
+	if fc.Seed != 0 {
+		cfg.Seed = fc.Seed
+	}
+	if err := setDuration(&cfg.Timeout, "timeout", fc.Timeout); err != nil {
+		return err
+	}
+	if fc.Retry != nil {
+		retry := cfg.retry()
+		retry.MaxAttempts = fc.Retry.MaxAttempts
+		if err := setDuration(&retry.InitialBackoff, "retry.initial_backoff", fc.Retry.InitialBackoff); err != nil {
+			return err
+		}
+		if err := setDuration(&retry.MaxBackoff, "retry.max_backoff", fc.Retry.MaxBackoff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cfg *Config) loadEnv() error {
+	setString(&cfg.BaseURL, os.Getenv(EnvBaseURL))
+	setString(&cfg.APIKey, os.Getenv(EnvAPIKey))
+	setString(&cfg.UserAgent, os.Getenv(EnvUserAgent))
+	setString(&cfg.ProxyURL, os.Getenv(EnvProxyURL))
+	setString(&cfg.Source, os.Getenv(EnvSource))
+	if err := setDuration(&cfg.Timeout, EnvTimeout, os.Getenv(EnvTimeout)); err != nil {
+		return err
+	}
+	if v := os.Getenv(EnvSeed); v != "" {
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", EnvSeed, v, err)
+		}
+		cfg.Seed = n
+	}
+
+	if v := os.Getenv(EnvRetryMaxAttempts); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", EnvRetryMaxAttempts, v, err)
+		}
+		cfg.retry().MaxAttempts = n
+	}
+	if v := os.Getenv(EnvRetryInitialBackoff); v != "" {
+		if err := setDuration(&cfg.retry().InitialBackoff, EnvRetryInitialBackoff, v); err != nil {
+			return err
+		}
+	}
+	if v := os.Getenv(EnvRetryMaxBackoff); v != "" {
+		if err := setDuration(&cfg.retry().MaxBackoff, EnvRetryMaxBackoff, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retry returns cfg.Retry, enabling retries if needed.
+func (cfg *Config) retry() *RetryPolicy {
+	if cfg.Retry == nil {
+		cfg.Retry = &RetryPolicy{}
+	}
+	return cfg.Retry
+}
+
+func setString(dst *string, v string) {
+	if v != "" {
+		*dst = v
+	}
+}
+
+func setDuration(dst *time.Duration, name, v string) error {
+	if v == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", name, v, err)
+	}
+	*dst = d
+	return nil
+}
+
+// NewClientFromEnv creates a client configured by LoadConfig(""). opts are
+// applied after the loaded configuration and therefore take precedence over
+// it; note that WithHTTPClient replaces the configured timeout, while the
+// proxy and retry settings are kept.
+func NewClientFromEnv(opts ...ClientOption) (*Client, error) {
+	cfg, err := LoadConfig("")
+	if err != nil {
+		return nil, err
+	}
+	return NewClientFromConfig(cfg, opts...)
+}
+
+// NewClientFromConfig creates a client from cfg. opts are applied last and
+// take precedence over cfg.
+func NewClientFromConfig(cfg Config, opts ...ClientOption) (*Client, error) {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultBaseURL
+	}
+	if cfg.Timeout < 0 {
+		return nil, errors.New("timeout must not be negative")
+	}
+
+	baseOpts := []ClientOption{
+		WithHTTPClient(&http.Client{Timeout: cfg.Timeout}),
+		WithUserAgent(cfg.UserAgent),
+	}
+	if cfg.ProxyURL != "" {
+		baseOpts = append(baseOpts, WithProxy(cfg.ProxyURL))
+	}
+	if cfg.APIKey != "" {
+		baseOpts = append(baseOpts, WithAPIKey(cfg.APIKey))
+	}
+	if cfg.Retry != nil {
+		baseOpts = append(baseOpts, WithRetryPolicy(*cfg.Retry))
+	}
+
+	return NewClient(cfg.BaseURL, append(baseOpts, opts...)...)
+}
+
+// String formats cfg with the API key and any proxy password redacted, so
+// that configurations can be logged safely.
+func (cfg Config) String() string {
+	apiKey := cfg.APIKey
+	if apiKey != "" {
+		apiKey = redacted
+	}
+	proxyURL := cfg.ProxyURL
+	if u, err := url.Parse(proxyURL); err == nil {
+		proxyURL = u.Redacted()
+	}
+	retry := "<nil>"
+	if cfg.Retry != nil {
+		retry = fmt.Sprintf("%+v", *cfg.Retry)
+	}
+	return fmt.Sprintf("{BaseURL:%s Timeout:%s APIKey:%s UserAgent:%s ProxyURL:%s Retry:%s Source:%s Seed:%d}",
+		cfg.BaseURL, cfg.Timeout, apiKey, cfg.UserAgent, proxyURL, retry, cfg.Source, cfg.Seed)
+}
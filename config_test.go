@@ -0,0 +1,133 @@
+package uuidify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	t.Setenv(EnvConfigFile, "")
+
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if cfg.BaseURL != DefaultBaseURL || cfg.Timeout != defaultHTTPTimeout || cfg.UserAgent != defaultUserAgent {
+		t.Fatalf("unexpected defaults %+v", cfg)
+	}
+	if cfg.Retry != nil {
+		t.Fatalf("expected retries to be disabled, got %+v", cfg.Retry)
+	}
+}
+
+func TestLoadConfig_FilesAndPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "uuidify.yaml")
+	writeFile(t, yamlPath, `
+base_url: https://file.example.com
+timeout: 2s
+api_key: file-key
+retry:
+  max_attempts: 5
+  initial_backoff: 200ms
+`)
+	jsonPath := filepath.Join(dir, "uuidify.json")
+	writeFile(t, jsonPath, `{"base_url":"https://json.example.com","timeout":"3s","proxy_url":"http://proxy.example.com:3128"}`)
+
+	t.Setenv(EnvConfigFile, yamlPath)
+	t.Setenv(EnvTimeout, "750ms")
+	t.Setenv(EnvRetryMaxBackoff, "1s")
+
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if cfg.BaseURL != "https://file.example.com" || cfg.APIKey != "file-key" {
+		t.Fatalf("expected file values, got %+v", cfg)
+	}
+	if cfg.Timeout != 750*time.Millisecond {
+		t.Fatalf("expected environment to override timeout, got %v", cfg.Timeout)
+	}
+	want := RetryPolicy{MaxAttempts: 5, InitialBackoff: 200 * time.Millisecond, MaxBackoff: time.Second}
+	if cfg.Retry == nil || *cfg.Retry != want {
+		t.Fatalf("expected retry %+v, got %+v", want, cfg.Retry)
+	}
+
+	cfg, err = LoadConfig(jsonPath)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if cfg.BaseURL != "https://json.example.com" || cfg.ProxyURL != "http://proxy.example.com:3128" {
+		t.Fatalf("expected JSON values, got %+v", cfg)
+	}
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	unknown := filepath.Join(dir, "unknown.yaml")
+	writeFile(t, unknown, "base_uri: https://typo.example.com\n")
+	toml := filepath.Join(dir, "uuidify.toml")
+	writeFile(t, toml, "base_url = \"x\"\n")
+	t.Setenv(EnvConfigFile, "")
+
+	for _, path := range []string{unknown, toml, filepath.Join(dir, "missing.json")} {
+		if _, err := LoadConfig(path); err == nil {
+			t.Fatalf("expected error for %s", path)
+		}
+	}
+
+	t.Setenv(EnvTimeout, "soon")
+	if _, err := LoadConfig(""); err == nil {
+		t.Fatal("expected error for invalid timeout")
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if got := r.Header.Get("X-API-Key"); got != "secret" {
+			t.Errorf("expected API key header, got %q", got)
+		}
+		if got := r.Header.Get("User-Agent"); got != "explicit-agent" {
+			t.Errorf("expected explicit user agent, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"uuid":"550e8400-e29b-41d4-a716-446655440000"}`))
+	}))
+	defer ts.Close()
+
+	t.Setenv(EnvConfigFile, "")
+	t.Setenv(EnvBaseURL, ts.URL)
+	t.Setenv(EnvAPIKey, "secret")
+	t.Setenv(EnvUserAgent, "env-agent")
+	t.Setenv(EnvRetryMaxAttempts, "2")
+	t.Setenv(EnvRetryInitialBackoff, "1ms")
+
+	c, err := NewClientFromEnv(WithUserAgent("explicit-agent"))
+	if err != nil {
+		t.Fatalf("NewClientFromEnv returned error: %v", err)
+	}
+	if _, err := c.UUIDv4(context.Background()); err != nil {
+		t.Fatalf("UUIDv4 returned error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 calls, got %d", got)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+}
@@ -0,0 +1,407 @@
+package uuidify
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultEndpointFailureThreshold = 3
+	defaultEndpointCooldown         = 30 * time.Second
+	defaultHedgeDelay               = 100 * time.Millisecond
+
+	// latencySamples is the number of recent latencies the hedge percentile
+	// is computed over, and minLatencySamples the number required before it
+	// replaces EndpointConfig.HedgeDelay.
+	latencySamples    = 128
+	minLatencySamples = 16
+)
+
+// Endpoint is a UUIDify API base URL used by WithEndpoints.
+type Endpoint struct {
+	URL string
+	// Priority orders endpoints; lower values are preferred. Endpoints with
+	// equal priority keep the order in which they were given.
+	Priority int
+}
+
+// EndpointConfig configures WithEndpoints. Zero fields fall back to defaults.
+type EndpointConfig struct {
+	// FailureThreshold is the number of consecutive failures after which an
+	// endpoint is considered unhealthy. Defaults to 3.
+	FailureThreshold int
+
+	// Cooldown is how long an unhealthy endpoint is tried only after every
+	// healthy one. Defaults to 30s.
+	Cooldown time.Duration
+
+	// HedgePercentile, if set, enables hedged requests: when the first
+	// endpoint has not answered within this percentile of recent request
+	// latencies, the same request is also sent to the next endpoint and
+	// whichever response arrives first wins. Must be between 0 and 1,
+	// e.g. 0.95.
+	HedgePercentile float64
+
+	// HedgeDelay is the minimum delay before a hedged request, also used
+	// until enough latencies have been recorded. Defaults to 100ms.
+	HedgeDelay time.Duration
+
+	// HealthCheckInterval, if set, enables active health checks: an
+	// unhealthy endpoint is probed with GET /?count=1 at this interval and
+	// restored as soon as a probe succeeds, rather than only after
+	// Cooldown.
+	HealthCheckInterval time.Duration
+}
+
+// WithEndpoints spreads requests over several UUIDify deployments, replacing
+// the client's base URL. Each request goes to the preferred healthy endpoint
+// and fails over to the next one on transport errors and 5xx responses.
+//
+// Health is tracked from the outcome of real requests: an endpoint that fails
+// FailureThreshold times in a row is skipped for Cooldown, then tried again.
+// With HealthCheckInterval set, it is also probed in the background while
+// skipped. Unhealthy endpoints remain a last resort when every healthy one
+// fails.
+func WithEndpoints(endpoints []Endpoint, cfg EndpointConfig) ClientOption {
+	return func(c *Client) error {
+		if len(endpoints) == 0 {
+			return errors.New("at least one endpoint is required")
+		}
+		if cfg.FailureThreshold < 0 || cfg.Cooldown < 0 || cfg.HedgeDelay < 0 || cfg.HealthCheckInterval < 0 {
+			return errors.New("endpoint config values must not be negative")
+		}
+		if cfg.HedgePercentile < 0 || cfg.HedgePercentile >= 1 {
+			return errors.New("hedge percentile must be between 0 and 1")
+		}
+		if cfg.FailureThreshold == 0 {
+			cfg.FailureThreshold = defaultEndpointFailureThreshold
+		}
+		if cfg.Cooldown == 0 {
+			cfg.Cooldown = defaultEndpointCooldown
+		}
+		if cfg.HedgeDelay == 0 {
+			cfg.HedgeDelay = defaultHedgeDelay
+		}
+
+		pool := &endpointPool{cfg: cfg}
+		for _, e := range endpoints {
+			u, err := url.Parse(e.URL)
+			if err != nil {
+				return err
+			}
+			if u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("endpoint %q must be an absolute URL", e.URL)
+			}
+			if !strings.HasSuffix(u.Path, "/") {
+				u.Path += "/"
+			}
+			pool.endpoints = append(pool.endpoints, &endpoint{base: u, priority: e.Priority})
+		}
+		slices.SortStableFunc(pool.endpoints, func(a, b *endpoint) int {
+			return cmp.Compare(a.priority, b.priority)
+		})
+
+		transportFor(c).endpoints = pool
+		return nil
+	}
+}
+
+type endpoint struct {
+	base     *url.URL
+	priority int
+
+	mu        sync.Mutex
+	failures  int
+	downUntil time.Time
+	probing   bool
+}
+
+func (e *endpoint) healthy(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return !now.Before(e.downUntil)
+}
+
+// record updates the health of e after a request. It reports whether a
+// health check should be started because e was just marked unhealthy.
+func (e *endpoint) record(failed bool, now time.Time, cfg EndpointConfig) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !failed {
+		e.failures = 0
+		e.downUntil = time.Time{}
+		return false
+	}
+	e.failures++
+	if e.failures < cfg.FailureThreshold {
+		return false
+	}
+	e.failures = 0
+	e.downUntil = now.Add(cfg.Cooldown)
+	if cfg.HealthCheckInterval == 0 || e.probing {
+		return false
+	}
+	e.probing = true
+	return true
+}
+
+// stillDown reports whether e is unhealthy, ending its health check if not.
+func (e *endpoint) stillDown(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !now.Before(e.downUntil) {
+		e.probing = false
+		return false
+	}
+	return true
+}
+
+// request returns a copy of req addressed to e. The API has a single
+// operation at the root of its base URL, so only the query is carried over.
+func (e *endpoint) request(ctx context.Context, req *http.Request) (*http.Request, error) {
+	r, err := cloneRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	r = r.WithContext(ctx)
+	u := *e.base
+	u.RawQuery = req.URL.RawQuery
+	r.URL = &u
+	r.Host = u.Host
+	return r, nil
+}
+
+type endpointPool struct {
+	cfg       EndpointConfig
+	endpoints []*endpoint
+
+	mu        sync.Mutex
+	latencies []time.Duration
+	next      int
+}
+
+// candidates returns the endpoints in the order they should be tried:
+// healthy ones by priority, then unhealthy ones by priority.
+func (p *endpointPool) candidates(now time.Time) []*endpoint {
+	healthy := make([]*endpoint, 0, len(p.endpoints))
+	var down []*endpoint
+	for _, e := range p.endpoints {
+		if e.healthy(now) {
+			healthy = append(healthy, e)
+		} else {
+			down = append(down, e)
+		}
+	}
+	return append(healthy, down...)
+}
+
+func (p *endpointPool) observeLatency(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.latencies) < latencySamples {
+		p.latencies = append(p.latencies, d)
+		return
+	}
+	p.latencies[p.next] = d
+	p.next = (p.next + 1) % latencySamples
+}
+
+// hedgeDelay returns how long to wait for the first endpoint before sending
+// a hedged request.
+func (p *endpointPool) hedgeDelay() time.Duration {
+	p.mu.Lock()
+	samples := slices.Clone(p.latencies)
+	p.mu.Unlock()
+	if len(samples) < minLatencySamples {
+		return p.cfg.HedgeDelay
+	}
+	slices.Sort(samples)
+	d := samples[int(p.cfg.HedgePercentile*float64(len(samples)-1))]
+	return max(d, p.cfg.HedgeDelay)
+}
+
+type endpointResult struct {
+	index  int
+	resp   *http.Response
+	err    error
+	cancel context.CancelFunc
+}
+
+// failed reports whether the result should trigger a failover.
+func (r endpointResult) failed() bool {
+	return r.err != nil || r.resp.StatusCode >= http.StatusInternalServerError
+}
+
+func (r endpointResult) close() {
+	if r.resp != nil {
+		drainAndClose(r.resp.Body)
+	}
+	if r.cancel != nil {
+		r.cancel()
+	}
+}
+
+// keep hands the response to the caller, releasing its request context once
+// the body is closed.
+func (r endpointResult) keep() (*http.Response, error) {
+	if r.resp == nil {
+		r.cancel()
+		return nil, r.err
+	}
+	r.resp.Body = &cancelOnClose{ReadCloser: r.resp.Body, cancel: r.cancel}
+	return r.resp, r.err
+}
+
+// do sends req to the best endpoint, failing over on errors and, if enabled,
+// hedging a slow request once per call.
+func (p *endpointPool) do(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	ctx := req.Context()
+	candidates := p.candidates(time.Now())
+	results := make(chan endpointResult, len(candidates))
+
+	var cancels []context.CancelFunc
+	next, inflight := 0, 0
+	launch := func() error {
+		e := candidates[next]
+		next++
+		attemptCtx, cancel := context.WithCancel(ctx)
+		r, err := e.request(attemptCtx, req)
+		if err != nil {
+			cancel()
+			return err
+		}
+		index := len(cancels)
+		cancels = append(cancels, cancel)
+		inflight++
+		go func() {
+			start := time.Now()
+			resp, err := send(r)
+			res := endpointResult{index: index, resp: resp, err: err, cancel: cancel}
+			// Outcomes of abandoned requests say nothing about the endpoint.
+			if attemptCtx.Err() == nil {
+				if e.record(res.failed(), time.Now(), p.cfg) {
+					go p.healthCheck(e, r.Clone(context.Background()), send)
+				}
+				if !res.failed() {
+					p.observeLatency(time.Since(start))
+				}
+			}
+			results <- res
+		}()
+		return nil
+	}
+	// abandon cancels the requests still in flight and cleans them up in
+	// the background.
+	abandon := func() {
+		for _, cancel := range cancels {
+			if cancel != nil {
+				cancel()
+			}
+		}
+		go func(n int) {
+			for ; n > 0; n-- {
+				(<-results).close()
+			}
+		}(inflight)
+	}
+
+	if err := launch(); err != nil {
+		return nil, err
+	}
+	var hedge <-chan time.Time
+	if p.cfg.HedgePercentile > 0 && len(candidates) > 1 {
+		timer := time.NewTimer(p.hedgeDelay())
+		defer timer.Stop()
+		hedge = timer.C
+	}
+
+	var last endpointResult
+	for inflight > 0 {
+		select {
+		case <-hedge:
+			hedge = nil
+			if next < len(candidates) {
+				if err := launch(); err != nil {
+					abandon()
+					return nil, err
+				}
+			}
+			continue
+		case res := <-results:
+			inflight--
+			if !res.failed() {
+				last.close()
+				// The winner's context must outlive this call, so it is
+				// dropped before the losers are canceled.
+				cancels[res.index] = nil
+				abandon()
+				return res.keep()
+			}
+			last.close()
+			last = res
+		}
+
+		if inflight == 0 && next < len(candidates) && ctx.Err() == nil {
+			if err := launch(); err != nil {
+				last.close()
+				return nil, err
+			}
+		}
+	}
+	return last.keep()
+}
+
+// healthCheck probes e every HealthCheckInterval until it is healthy again,
+// sending through send with the headers of tmpl, the request that failed.
+func (p *endpointPool) healthCheck(e *endpoint, tmpl *http.Request, send func(*http.Request) (*http.Response, error)) {
+	ticker := time.NewTicker(p.cfg.HealthCheckInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !e.stillDown(time.Now()) {
+			return
+		}
+		if p.probe(tmpl, send) {
+			e.record(false, time.Now(), p.cfg)
+		}
+	}
+}
+
+// probe sends GET /?count=1 to the endpoint of tmpl and reports whether it
+// answered without a transport error or 5xx response.
+func (p *endpointPool) probe(tmpl *http.Request, send func(*http.Request) (*http.Response, error)) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), p.cfg.HealthCheckInterval)
+	defer cancel()
+	r := tmpl.Clone(ctx)
+	r.Method = http.MethodGet
+	r.Body, r.GetBody, r.ContentLength = nil, nil, 0
+	r.URL.RawQuery = "count=1"
+
+	resp, err := send(r)
+	res := endpointResult{resp: resp, err: err}
+	if resp != nil {
+		drainAndClose(resp.Body)
+	}
+	return !res.failed()
+}
+
+// cancelOnClose releases the request context of a winning response once its
+// body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
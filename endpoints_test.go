@@ -0,0 +1,247 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	primaryUUID   = "550e8400-e29b-41d4-a716-446655440000"
+	secondaryUUID = "6ba7b810-9dad-41d1-80b4-00c04fd430c8"
+)
+
+func TestWithEndpoints_FailsOver(t *testing.T) {
+	t.Parallel()
+
+	var primaryCalls, secondaryCalls atomic.Int32
+	primary := newEndpointServer(&primaryCalls, http.StatusServiceUnavailable, primaryUUID, 0)
+	defer primary.Close()
+	secondary := newEndpointServer(&secondaryCalls, http.StatusOK, secondaryUUID, 0)
+	defer secondary.Close()
+
+	c, err := NewClient(primary.URL, WithEndpoints([]Endpoint{
+		{URL: secondary.URL, Priority: 2},
+		{URL: primary.URL, Priority: 1},
+	}, EndpointConfig{FailureThreshold: 2, Cooldown: time.Minute}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		id, err := c.UUIDv4(context.Background())
+		if err != nil {
+			t.Fatalf("UUIDv4 returned error: %v", err)
+		}
+		if id != secondaryUUID {
+			t.Fatalf("expected id from secondary, got %s", id)
+		}
+	}
+
+	// The primary is tried first until it is marked unhealthy.
+	if got := primaryCalls.Load(); got != 2 {
+		t.Fatalf("expected 2 calls to primary, got %d", got)
+	}
+	if got := secondaryCalls.Load(); got != 3 {
+		t.Fatalf("expected 3 calls to secondary, got %d", got)
+	}
+}
+
+func TestWithEndpoints_AllFail(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	a := newEndpointServer(&calls, http.StatusServiceUnavailable, primaryUUID, 0)
+	defer a.Close()
+	b := newEndpointServer(&calls, http.StatusBadGateway, secondaryUUID, 0)
+	defer b.Close()
+
+	c, err := NewClient(a.URL, WithEndpoints([]Endpoint{{URL: a.URL}, {URL: b.URL}}, EndpointConfig{}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = c.UUIDv4(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected 502 APIError from the last endpoint, got %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestWithEndpoints_Hedges(t *testing.T) {
+	t.Parallel()
+
+	var slowCalls, fastCalls atomic.Int32
+	slow := newEndpointServer(&slowCalls, http.StatusOK, primaryUUID, time.Second)
+	defer slow.Close()
+	fast := newEndpointServer(&fastCalls, http.StatusOK, secondaryUUID, 0)
+	defer fast.Close()
+
+	c, err := NewClient(slow.URL, WithEndpoints([]Endpoint{{URL: slow.URL}, {URL: fast.URL}}, EndpointConfig{
+		HedgePercentile: 0.95,
+		HedgeDelay:      20 * time.Millisecond,
+	}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	start := time.Now()
+	id, err := c.UUIDv4(context.Background())
+	if err != nil {
+		t.Fatalf("UUIDv4 returned error: %v", err)
+	}
+	if id != secondaryUUID {
+		t.Fatalf("expected hedged id, got %s", id)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected hedged response well before the slow endpoint, took %v", elapsed)
+	}
+	if slowCalls.Load() != 1 || fastCalls.Load() != 1 {
+		t.Fatalf("expected one call per endpoint, got %d and %d", slowCalls.Load(), fastCalls.Load())
+	}
+}
+
+func TestWithEndpoints_HealthCheck(t *testing.T) {
+	t.Parallel()
+
+	var down atomic.Bool
+	var probes atomic.Int32
+	down.Store(true)
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("count") == "1" {
+			probes.Add(1)
+		}
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"uuid":"` + primaryUUID + `"}`))
+	}))
+	defer primary.Close()
+	var secondaryCalls atomic.Int32
+	secondary := newEndpointServer(&secondaryCalls, http.StatusOK, secondaryUUID, 0)
+	defer secondary.Close()
+
+	c, err := NewClient(primary.URL, WithEndpoints([]Endpoint{
+		{URL: primary.URL, Priority: 1},
+		{URL: secondary.URL, Priority: 2},
+	}, EndpointConfig{FailureThreshold: 1, Cooldown: time.Hour, HealthCheckInterval: 5 * time.Millisecond}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if id, err := c.UUIDv4(context.Background()); err != nil || id != secondaryUUID {
+		t.Fatalf("expected id from secondary, got %s (%v)", id, err)
+	}
+
+	// Failed probes keep the primary out of rotation.
+	for probes.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	if id, err := c.UUIDv4(context.Background()); err != nil || id != secondaryUUID {
+		t.Fatalf("expected id from secondary while primary is down, got %s (%v)", id, err)
+	}
+
+	down.Store(false)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		id, err := c.UUIDv4(context.Background())
+		if err != nil {
+			t.Fatalf("UUIDv4 returned error: %v", err)
+		}
+		if id == primaryUUID {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("primary was not restored by the health check")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWithEndpoints_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		endpoints []Endpoint
+		cfg       EndpointConfig
+	}{
+		{nil, EndpointConfig{}},
+		{[]Endpoint{{URL: "api.uuidify.io"}}, EndpointConfig{}},
+		{[]Endpoint{{URL: DefaultBaseURL}}, EndpointConfig{Cooldown: -time.Second}},
+		{[]Endpoint{{URL: DefaultBaseURL}}, EndpointConfig{HedgePercentile: 1}},
+		{[]Endpoint{{URL: DefaultBaseURL}}, EndpointConfig{HealthCheckInterval: -time.Second}},
+	}
+	for _, tc := range cases {
+		if _, err := NewClient(DefaultBaseURL, WithEndpoints(tc.endpoints, tc.cfg)); err == nil {
+			t.Fatalf("expected error for %+v", tc)
+		}
+	}
+}
+
+func TestWithEndpoints_ExtremePriorities(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewClient(DefaultBaseURL, WithEndpoints([]Endpoint{
+		{URL: "https://low.example.com", Priority: math.MaxInt},
+		{URL: "https://high.example.com", Priority: math.MinInt},
+		{URL: "https://mid.example.com"},
+	}, EndpointConfig{}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var got []string
+	for _, e := range lookupTransport(c).endpoints.endpoints {
+		got = append(got, e.base.Host)
+	}
+	want := []string{"high.example.com", "mid.example.com", "low.example.com"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected order %v, got %v", want, got)
+	}
+}
+
+func TestEndpointPool_HedgeDelay(t *testing.T) {
+	t.Parallel()
+
+	p := &endpointPool{cfg: EndpointConfig{HedgePercentile: 0.9, HedgeDelay: 5 * time.Millisecond}}
+	if got := p.hedgeDelay(); got != 5*time.Millisecond {
+		t.Fatalf("expected configured delay without samples, got %v", got)
+	}
+	for i := 1; i <= 100; i++ {
+		p.observeLatency(time.Duration(i) * time.Millisecond)
+	}
+	if got := p.hedgeDelay(); got != 90*time.Millisecond {
+		t.Fatalf("expected p90 of 90ms, got %v", got)
+	}
+}
+
+func newEndpointServer(calls *atomic.Int32, status int, id string, latency time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			w.Write([]byte(`{"error":"unavailable"}`))
+			return
+		}
+		w.Write([]byte(`{"uuid":"` + id + `"}`))
+	}))
+}
@@ -0,0 +1,102 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ilkereroglu/uuidify-go/internal/idgen"
+)
+
+// FallbackEvent describes an API call that was served by local generation.
+type FallbackEvent struct {
+	Version GetParamsVersion
+	Count   int
+	// Err is the API error that triggered the fallback.
+	Err error
+}
+
+// WithLocalFallback generates RFC 9562 compliant identifiers in-process when
+// the API is unreachable: on transport errors and on 5xx responses that
+// remain after any configured retries. Requests rejected with 4xx statuses
+// and calls whose context is done still fail.
+//
+// onFallback, if non-nil, is invoked synchronously for every call served
+// locally so that fallback usage can be alerted on. Results returned by
+// GenerateUUIDs and GenerateULIDs report Local as true in that case.
+func WithLocalFallback(onFallback func(context.Context, FallbackEvent)) ClientOption {
+	return func(c *Client) error {
+		transportFor(c).fallback = &localFallback{
+			gen:        idgen.New(nil, nil),
+			onFallback: onFallback,
+		}
+		return nil
+	}
+}
+
+type localFallback struct {
+	gen        *idgen.Generator
+	onFallback func(context.Context, FallbackEvent)
+}
+
+func (f *localFallback) generate(ctx context.Context, version GetParamsVersion, count int, cause error) ([]string, *payload, error) {
+	ids := make([]string, count)
+	for i := range ids {
+		id, err := generateLocal(f.gen, version)
+		if err != nil {
+			return nil, nil, errors.Join(cause, err)
+		}
+		ids[i] = id
+	}
+
+	if f.onFallback != nil {
+		f.onFallback(ctx, FallbackEvent{Version: version, Count: count, Err: cause})
+	}
+
+	return ids, &payload{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339Nano),
+		local:       true,
+		cause:       cause,
+	}, nil
+}
+
+// generateLocal produces one identifier of the given version with gen.
+func generateLocal(gen *idgen.Generator, version GetParamsVersion) (string, error) {
+	switch version {
+	case GetParamsVersionV1:
+		u, err := gen.V1()
+		return UUID(u).String(), err
+	case GetParamsVersionV4:
+		u, err := gen.V4()
+		return UUID(u).String(), err
+	case GetParamsVersionV7:
+		u, err := gen.V7()
+		return UUID(u).String(), err
+	case GetParamsVersionUlid:
+		id, err := gen.ULID()
+		return ULID(id).String(), err
+	default:
+		return "", fmt.Errorf("unsupported version %q", version)
+	}
+}
+
+// shouldFallback reports whether err means the API could not serve the call,
+// as opposed to rejecting it, holding it back to respect the quota, the
+// credentials being unavailable or the caller giving up.
+func shouldFallback(ctx context.Context, err error) bool {
+	if ctx != nil && ctx.Err() != nil {
+		return false
+	}
+	var authErr *AuthError
+	if errors.As(err, &authErr) || errors.Is(err, ErrRateLimited) {
+		return false
+	}
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= http.StatusInternalServerError
+}
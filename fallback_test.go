@@ -0,0 +1,91 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocalFallback_ServerError(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	var events []FallbackEvent
+	c, err := NewClient(ts.URL,
+		WithHTTPClient(ts.Client()),
+		WithLocalFallback(func(_ context.Context, ev FallbackEvent) {
+			events = append(events, ev)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	res, err := c.GenerateUUIDs(context.Background(), "v7", 3)
+	if err != nil {
+		t.Fatalf("GenerateUUIDs returned error: %v", err)
+	}
+	if !res.Local || len(res.IDs) != 3 || res.GeneratedAt.IsZero() {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	for _, id := range res.IDs {
+		if id.Version() != 7 || id.Variant() != VariantRFC4122 {
+			t.Fatalf("unexpected local id %s", id)
+		}
+	}
+
+	if len(events) != 1 || events[0].Count != 3 || events[0].Version != GetParamsVersionV7 {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	var apiErr *APIError
+	if !errors.As(events[0].Err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected 502 cause, got %v", events[0].Err)
+	}
+}
+
+func TestLocalFallback_TransportError(t *testing.T) {
+	t.Parallel()
+
+	client := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("boom")
+	})}
+
+	c, err := NewClient("https://example.com", WithHTTPClient(client), WithLocalFallback(nil))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	id, err := c.ULID(context.Background())
+	if err != nil {
+		t.Fatalf("ULID returned error: %v", err)
+	}
+	if _, err := ParseULID(id); err != nil {
+		t.Fatalf("invalid local ULID %q: %v", id, err)
+	}
+}
+
+func TestLocalFallback_NotOnBadRequest(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"error":"Invalid version parameter"}`)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, WithHTTPClient(ts.Client()), WithLocalFallback(nil))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := c.UUIDv1(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
@@ -0,0 +1,126 @@
+package uuidify
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLocalGenerator(t *testing.T) {
+	t.Parallel()
+
+	var g Generator = NewLocalGenerator()
+	ctx := context.Background()
+
+	for version, gen := range map[GetParamsVersion]func(context.Context) (string, error){
+		GetParamsVersionV1: g.UUIDv1,
+		GetParamsVersionV4: g.UUIDv4,
+		GetParamsVersionV7: g.UUIDv7,
+	} {
+		s, err := gen(ctx)
+		if err != nil {
+			t.Fatalf("%s returned error: %v", version, err)
+		}
+		if err := validateUUIDs(version, []string{s}); err != nil {
+			t.Fatalf("%s produced invalid id: %v", version, err)
+		}
+	}
+
+	ids, err := g.ULIDBatch(ctx, 10)
+	if err != nil {
+		t.Fatalf("ULIDBatch returned error: %v", err)
+	}
+	if err := validateULIDs(ids); err != nil {
+		t.Fatalf("ULIDBatch produced invalid id: %v", err)
+	}
+
+	if _, err := g.UUIDBatch(ctx, "ulid", 2); err == nil {
+		t.Fatal("expected version error")
+	}
+	if _, err := g.UUIDBatch(ctx, "v4", 1001); err == nil {
+		t.Fatal("expected count error")
+	}
+}
+
+func TestSeededGenerator_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 11, 15, 1, 0, 0, 0, time.UTC)
+	a := NewSeededGenerator(7, start)
+	b := NewSeededGenerator(7, start)
+	ctx := context.Background()
+
+	x, err := a.UUIDBatch(ctx, "v7", 5)
+	if err != nil {
+		t.Fatalf("UUIDBatch returned error: %v", err)
+	}
+	y, _ := b.UUIDBatch(ctx, "v7", 5)
+	for i := range x {
+		if x[i] != y[i] {
+			t.Fatalf("expected identical sequences, got %v and %v", x, y)
+		}
+	}
+
+	ts, err := Timestamp(x[2])
+	if err != nil {
+		t.Fatalf("Timestamp returned error: %v", err)
+	}
+	if want := start.Add(2 * time.Millisecond); !ts.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, ts)
+	}
+
+	other, _ := NewSeededGenerator(8, start).UUIDv7(ctx)
+	if other == x[0] {
+		t.Fatal("expected different seeds to diverge")
+	}
+}
+
+func TestNewGenerator(t *testing.T) {
+	t.Parallel()
+
+	for source, want := range map[string]string{
+		"":           "*uuidify.Client",
+		SourceRemote: "*uuidify.Client",
+		SourceLocal:  "*uuidify.LocalGenerator",
+		SourceSeeded: "*uuidify.LocalGenerator",
+	} {
+		g, err := NewGenerator(Config{Source: source})
+		if err != nil {
+			t.Fatalf("%q: NewGenerator returned error: %v", source, err)
+		}
+		if got := fmt.Sprintf("%T", g); got != want {
+			t.Fatalf("%q: expected %s, got %s", source, want, got)
+		}
+	}
+
+	if _, err := NewGenerator(Config{Source: "mock"}); err == nil {
+		t.Fatal("expected error for unknown source")
+	}
+}
+
+func TestNewGeneratorFromEnv_Seeded(t *testing.T) {
+	t.Setenv(EnvConfigFile, "")
+	t.Setenv(EnvSource, SourceSeeded)
+	t.Setenv(EnvSeed, "42")
+
+	g, err := NewGeneratorFromEnv()
+	if err != nil {
+		t.Fatalf("NewGeneratorFromEnv returned error: %v", err)
+	}
+	ctx := context.Background()
+	got, err := g.UUIDBatch(ctx, "v7", 3)
+	if err != nil {
+		t.Fatalf("UUIDBatch returned error: %v", err)
+	}
+	want, _ := NewSeededGenerator(42, time.Unix(0, 0)).UUIDBatch(ctx, "v7", 3)
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	t.Setenv(EnvSeed, "-1")
+	if _, err := NewGeneratorFromEnv(); err == nil {
+		t.Fatal("expected error for invalid seed")
+	}
+}
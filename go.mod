@@ -1,9 +1,17 @@
 module github.com/ilkereroglu/uuidify-go
 
-go 1.24
+go 1.24.0
+
+require (
+	github.com/oapi-codegen/runtime v1.1.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/apapsch/go-jsonmerge/v2 v2.0.0 // indirect
-	github.com/google/uuid v1.4.0 // indirect
-	github.com/oapi-codegen/runtime v1.1.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/kr/pretty v0.3.1 // indirect
+	github.com/rogpeppe/go-internal v1.14.1 // indirect
+	github.com/stretchr/testify v1.11.1 // indirect
+	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
 )
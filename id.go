@@ -0,0 +1,234 @@
+package uuidify
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// UUID is a 128-bit universally unique identifier as described by RFC 9562.
+//
+// The layout matches github.com/google/uuid.UUID, so values convert directly
+// in either direction with a plain type conversion.
+type UUID [16]byte
+
+// ULID is a 128-bit Universally Unique Lexicographically Sortable Identifier:
+// a 48-bit big-endian millisecond timestamp followed by 80 bits of randomness.
+type ULID [16]byte
+
+// Version is the UUID version stored in the high nibble of octet 6.
+type Version byte
+
+// Variant is the UUID layout variant stored in the high bits of octet 8.
+type Variant byte
+
+// Known UUID variants.
+const (
+	VariantNCS Variant = iota
+	VariantRFC4122
+	VariantMicrosoft
+	VariantFuture
+)
+
+func (v Variant) String() string {
+	switch v {
+	case VariantNCS:
+		return "NCS"
+	case VariantRFC4122:
+		return "RFC4122"
+	case VariantMicrosoft:
+		return "Microsoft"
+	case VariantFuture:
+		return "Future"
+	default:
+		return fmt.Sprintf("Variant(%d)", byte(v))
+	}
+}
+
+// ParseUUID decodes s, which must be in the canonical lowercase
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx form.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	if len(s) != 36 {
+		return u, fmt.Errorf("invalid UUID %q: length must be 36, got %d", s, len(s))
+	}
+	if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("invalid UUID %q: misplaced hyphens", s)
+	}
+
+	j := 0
+	for i := 0; i < len(s); i += 2 {
+		if s[i] == '-' {
+			i--
+			continue
+		}
+		hi, ok1 := fromHexLower(s[i])
+		lo, ok2 := fromHexLower(s[i+1])
+		if !ok1 || !ok2 {
+			return UUID{}, fmt.Errorf("invalid UUID %q: non-canonical hex digit", s)
+		}
+		u[j] = hi<<4 | lo
+		j++
+	}
+	return u, nil
+}
+
+// MustParseUUID is like ParseUUID but panics if s cannot be parsed.
+func MustParseUUID(s string) UUID {
+	u, err := ParseUUID(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// String returns the canonical lowercase hyphenated form of u.
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// Bytes returns a copy of the 16 raw bytes of u.
+func (u UUID) Bytes() []byte {
+	b := make([]byte, len(u))
+	copy(b, u[:])
+	return b
+}
+
+// Version returns the version nibble of u.
+func (u UUID) Version() Version {
+	return Version(u[6] >> 4)
+}
+
+// Variant returns the layout variant of u.
+func (u UUID) Variant() Variant {
+	switch {
+	case u[8]&0x80 == 0x00:
+		return VariantNCS
+	case u[8]&0xc0 == 0x80:
+		return VariantRFC4122
+	case u[8]&0xe0 == 0xc0:
+		return VariantMicrosoft
+	default:
+		return VariantFuture
+	}
+}
+
+// IsZero reports whether u is the nil UUID.
+func (u UUID) IsZero() bool {
+	return u == UUID{}
+}
+
+// crockford is the Crockford base32 alphabet used by ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordIndex maps an uppercase Crockford character to its 5-bit value,
+// or 0xff when the character is not part of the alphabet.
+var crockfordIndex = func() [256]byte {
+	var idx [256]byte
+	for i := range idx {
+		idx[i] = 0xff
+	}
+	for i := 0; i < len(crockford); i++ {
+		idx[crockford[i]] = byte(i)
+	}
+	return idx
+}()
+
+// ParseULID decodes s, which must be 26 uppercase Crockford base32 characters.
+func ParseULID(s string) (ULID, error) {
+	var id ULID
+	if len(s) != 26 {
+		return id, fmt.Errorf("invalid ULID %q: length must be 26, got %d", s, len(s))
+	}
+	for i := 0; i < len(s); i++ {
+		if crockfordIndex[s[i]] == 0xff {
+			return id, fmt.Errorf("invalid ULID %q: invalid character %q", s, s[i])
+		}
+	}
+	// The first character carries only 3 significant bits; anything above
+	// '7' would overflow 128 bits.
+	if crockfordIndex[s[0]] > 7 {
+		return id, fmt.Errorf("invalid ULID %q: value overflows 128 bits", s)
+	}
+
+	// Each character contributes 5 bits; 26*5 = 130 bits, the first two of
+	// which are always zero.
+	var acc uint
+	var bits uint
+	j := 0
+	for i := 0; i < len(s); i++ {
+		acc = acc<<5 | uint(crockfordIndex[s[i]])
+		bits += 5
+		if i == 0 {
+			bits -= 2
+		}
+		for bits >= 8 {
+			bits -= 8
+			id[j] = byte(acc >> bits)
+			j++
+		}
+		acc &= 1<<bits - 1
+	}
+	return id, nil
+}
+
+// MustParseULID is like ParseULID but panics if s cannot be parsed.
+func MustParseULID(s string) ULID {
+	id, err := ParseULID(s)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// String returns the 26 character Crockford base32 form of id.
+func (id ULID) String() string {
+	var buf [26]byte
+	// Prepend two zero bits so the 128-bit value splits into 26 groups of 5.
+	var acc uint
+	var bits uint = 2
+	j := 0
+	for _, b := range id {
+		acc = acc<<8 | uint(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			buf[j] = crockford[(acc>>bits)&0x1f]
+			j++
+		}
+		acc &= 1<<bits - 1
+	}
+	return string(buf[:])
+}
+
+// Bytes returns a copy of the 16 raw bytes of id.
+func (id ULID) Bytes() []byte {
+	b := make([]byte, len(id))
+	copy(b, id[:])
+	return b
+}
+
+// IsZero reports whether id is the zero ULID.
+func (id ULID) IsZero() bool {
+	return id == ULID{}
+}
+
+func fromHexLower(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	default:
+		return 0, false
+	}
+}
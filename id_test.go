@@ -0,0 +1,136 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseUUID_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	const s = "550e8400-e29b-41d4-a716-446655440000"
+	u, err := ParseUUID(s)
+	if err != nil {
+		t.Fatalf("ParseUUID returned error: %v", err)
+	}
+	if got := u.String(); got != s {
+		t.Fatalf("expected %s, got %s", s, got)
+	}
+	if got := u.Version(); got != 4 {
+		t.Fatalf("expected version 4, got %d", got)
+	}
+	if got := u.Variant(); got != VariantRFC4122 {
+		t.Fatalf("expected variant RFC4122, got %s", got)
+	}
+	if u.IsZero() {
+		t.Fatal("expected non-zero UUID")
+	}
+	if b := u.Bytes(); len(b) != 16 || b[0] != 0x55 || b[15] != 0x00 {
+		t.Fatalf("unexpected bytes %x", b)
+	}
+}
+
+func TestParseUUID_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{
+		"",
+		"1234",
+		"550e8400e29b41d4a716446655440000",
+		"550e8400-e29b-41d4-a716-44665544000g",
+		"550E8400-E29B-41D4-A716-446655440000",
+		"550e8400-e29b-41d4a-716-446655440000",
+	} {
+		if _, err := ParseUUID(s); err == nil {
+			t.Fatalf("expected error for %q", s)
+		}
+	}
+}
+
+func TestParseULID_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]ULID{
+		"00000000000000000000000000": {},
+		"7ZZZZZZZZZZZZZZZZZZZZZZZZZ": {0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"00000000000000000000000001": {15: 0x01},
+	}
+	for s, want := range cases {
+		id, err := ParseULID(s)
+		if err != nil {
+			t.Fatalf("ParseULID(%q) returned error: %v", s, err)
+		}
+		if id != want {
+			t.Fatalf("ParseULID(%q) = %x, want %x", s, id, want)
+		}
+		if got := id.String(); got != s {
+			t.Fatalf("expected %s, got %s", s, got)
+		}
+	}
+
+	const s = "01HX7D9PMV4NQVP3J8B1R6R6FZ"
+	if got := MustParseULID(s).String(); got != s {
+		t.Fatalf("expected %s, got %s", s, got)
+	}
+}
+
+func TestParseULID_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{
+		"",
+		"01J123",
+		"80000000000000000000000000",
+		"01HX7D9PMV4NQVP3J8B1R6R6FU",
+		"01hx7d9pmv4nqvp3j8b1r6r6fz",
+	} {
+		if _, err := ParseULID(s); err == nil {
+			t.Fatalf("expected error for %q", s)
+		}
+	}
+}
+
+func TestTypedUUIDv4(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"uuid":"550e8400-e29b-41d4-a716-446655440000"}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+
+	u, err := c.TypedUUIDv4(context.Background())
+	if err != nil {
+		t.Fatalf("TypedUUIDv4 returned error: %v", err)
+	}
+	if u != MustParseUUID("550e8400-e29b-41d4-a716-446655440000") {
+		t.Fatalf("unexpected uuid %s", u)
+	}
+}
+
+func TestTypedULIDBatch_Malformed(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"ulids":["01HX7D9PMV4NQVP3J8B1R6R6FZ","nope"]}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+
+	if _, err := c.TypedULIDBatch(context.Background(), 2); err == nil {
+		t.Fatal("expected error, got nil")
+	} else {
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) {
+			t.Fatalf("expected ValidationError, got %T", err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package uuidify
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ilkereroglu/uuidify-go/internal/idgen"
+)
+
+// ErrNoTimestamp is returned when an identifier does not embed a timestamp,
+// as is the case for UUID v4.
+var ErrNoTimestamp = errors.New("uuidify: identifier has no embedded timestamp")
+
+// Timestamp returns the time embedded in id, which may be a UUID v1, a UUID
+// v7 or a ULID in canonical form.
+func Timestamp(id string) (time.Time, error) {
+	switch len(id) {
+	case 36:
+		u, err := ParseUUID(id)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return u.Time()
+	case 26:
+		ulid, err := ParseULID(id)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return ulid.Time(), nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid identifier %q: not a UUID or ULID", id)
+	}
+}
+
+// Time returns the creation time embedded in a v1 or v7 UUID, with 100ns and
+// millisecond precision respectively. Other versions return ErrNoTimestamp.
+func (u UUID) Time() (time.Time, error) {
+	switch u.Version() {
+	case 1:
+		// Split into seconds first: in nanoseconds the later v1 timestamps
+		// overflow an int64.
+		ticks := int64(u.v1Ticks()) - idgen.GregorianOffset
+		return time.Unix(ticks/1e7, ticks%1e7*100).UTC(), nil
+	case 7:
+		return time.UnixMilli(int64(millis48(u[:6]))).UTC(), nil
+	default:
+		return time.Time{}, fmt.Errorf("%w (version %d)", ErrNoTimestamp, u.Version())
+	}
+}
+
+// ClockSequence returns the 14-bit clock sequence of a v1 UUID.
+func (u UUID) ClockSequence() (int, error) {
+	if u.Version() != 1 {
+		return 0, fmt.Errorf("clock sequence is only defined for version 1, got %d", u.Version())
+	}
+	return int(binary.BigEndian.Uint16(u[8:10]) & 0x3fff), nil
+}
+
+// NodeID returns the 6-byte node identifier of a v1 UUID.
+func (u UUID) NodeID() ([]byte, error) {
+	if u.Version() != 1 {
+		return nil, fmt.Errorf("node ID is only defined for version 1, got %d", u.Version())
+	}
+	node := make([]byte, 6)
+	copy(node, u[10:])
+	return node, nil
+}
+
+// v1Ticks reassembles the 60-bit Gregorian timestamp of a v1 UUID from its
+// time_low, time_mid and time_hi fields.
+func (u UUID) v1Ticks() uint64 {
+	low := uint64(binary.BigEndian.Uint32(u[0:4]))
+	mid := uint64(binary.BigEndian.Uint16(u[4:6]))
+	hi := uint64(binary.BigEndian.Uint16(u[6:8]) & 0x0fff)
+	return hi<<48 | mid<<32 | low
+}
+
+// Time returns the millisecond timestamp embedded in id.
+func (id ULID) Time() time.Time {
+	return time.UnixMilli(int64(id.Timestamp())).UTC()
+}
+
+// Timestamp returns the 48-bit Unix millisecond timestamp of id.
+func (id ULID) Timestamp() uint64 {
+	return millis48(id[:6])
+}
+
+func millis48(b []byte) uint64 {
+	return uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(b[2])<<24 |
+		uint64(b[3])<<16 | uint64(b[4])<<8 | uint64(b[5])
+}
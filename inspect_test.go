@@ -0,0 +1,86 @@
+package uuidify
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimestamp_V1(t *testing.T) {
+	t.Parallel()
+
+	// Example from RFC 9562 appendix A.1.
+	u := MustParseUUID("c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	got, err := u.Time()
+	if err != nil {
+		t.Fatalf("Time returned error: %v", err)
+	}
+	want := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	seq, err := u.ClockSequence()
+	if err != nil || seq != 0x33c8 {
+		t.Fatalf("expected clock sequence 0x33c8, got %#x (%v)", seq, err)
+	}
+	node, err := u.NodeID()
+	if err != nil || len(node) != 6 || node[0] != 0x9f || node[5] != 0x46 {
+		t.Fatalf("unexpected node %x (%v)", node, err)
+	}
+}
+
+func TestTimestamp_V1Max(t *testing.T) {
+	t.Parallel()
+
+	got, err := MustParseUUID("ffffffff-ffff-1fff-bfff-ffffffffffff").Time()
+	if err != nil {
+		t.Fatalf("Time returned error: %v", err)
+	}
+	want := time.Date(5236, 3, 31, 21, 21, 0, 684697500, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestTimestamp_V7(t *testing.T) {
+	t.Parallel()
+
+	// Example from RFC 9562 appendix A.6.
+	got, err := Timestamp("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	if err != nil {
+		t.Fatalf("Timestamp returned error: %v", err)
+	}
+	want := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestTimestamp_ULID(t *testing.T) {
+	t.Parallel()
+
+	got, err := Timestamp("01ARYZ6S410000000000000000")
+	if err != nil {
+		t.Fatalf("Timestamp returned error: %v", err)
+	}
+	if ms := got.UnixMilli(); ms != 1469918176385 {
+		t.Fatalf("expected 1469918176385, got %d", ms)
+	}
+}
+
+func TestTimestamp_Errors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Timestamp("550e8400-e29b-41d4-a716-446655440000"); !errors.Is(err, ErrNoTimestamp) {
+		t.Fatalf("expected ErrNoTimestamp, got %v", err)
+	}
+	if _, err := MustParseUUID("550e8400-e29b-41d4-a716-446655440000").ClockSequence(); err == nil {
+		t.Fatal("expected clock sequence error for v4")
+	}
+	for _, s := range []string{"", "nope", "017f22e2-79b0-7cc3-98c4-dc0c0c07398g", "01ARYZ6S41000000000000000U"} {
+		if _, err := Timestamp(s); err == nil || errors.Is(err, ErrNoTimestamp) {
+			t.Fatalf("expected parse error for %q, got %v", s, err)
+		}
+	}
+}
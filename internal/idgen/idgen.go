@@ -0,0 +1,129 @@
+// Package idgen generates RFC 9562 UUIDs and ULIDs in-process.
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"sync"
+	"time"
+)
+
+// GregorianOffset is the number of 100ns intervals between the Gregorian
+// epoch (1582-10-15) used by UUID v1 and the Unix epoch.
+const GregorianOffset = 0x01b21dd213814000
+
+// Generator produces identifiers from a random source and a clock. It is safe
+// for concurrent use.
+type Generator struct {
+	mu   sync.Mutex
+	rand io.Reader
+	now  func() time.Time
+
+	v1Init   bool
+	v1Last   uint64
+	clockSeq uint16
+	node     [6]byte
+}
+
+// New returns a Generator reading randomness from r and time from now. A nil
+// r uses crypto/rand and a nil now uses time.Now.
+func New(r io.Reader, now func() time.Time) *Generator {
+	if r == nil {
+		r = rand.Reader
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &Generator{rand: r, now: now}
+}
+
+// V1 returns a time-based UUID with a random node identifier, as allowed by
+// RFC 9562 section 6.10. Timestamps are strictly increasing per Generator.
+func (g *Generator) V1() ([16]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var u [16]byte
+	if !g.v1Init {
+		var seed [8]byte
+		if _, err := io.ReadFull(g.rand, seed[:]); err != nil {
+			return u, err
+		}
+		g.clockSeq = binary.BigEndian.Uint16(seed[0:2]) & 0x3fff
+		copy(g.node[:], seed[2:8])
+		g.node[0] |= 0x01 // multicast bit marks a random node
+		g.v1Init = true
+	}
+
+	ts := uint64(g.now().UnixNano()/100) + GregorianOffset
+	if ts <= g.v1Last {
+		ts = g.v1Last + 1
+	}
+	g.v1Last = ts
+
+	binary.BigEndian.PutUint32(u[0:4], uint32(ts))
+	binary.BigEndian.PutUint16(u[4:6], uint16(ts>>32))
+	binary.BigEndian.PutUint16(u[6:8], uint16(ts>>48)&0x0fff|0x1000)
+	binary.BigEndian.PutUint16(u[8:10], g.clockSeq|0x8000)
+	copy(u[10:], g.node[:])
+	return u, nil
+}
+
+// V4 returns a random UUID.
+func (g *Generator) V4() ([16]byte, error) {
+	var u [16]byte
+	if err := g.read(u[:]); err != nil {
+		return u, err
+	}
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return u, nil
+}
+
+// V7 returns a UUID carrying the current Unix millisecond timestamp followed
+// by random bits.
+func (g *Generator) V7() ([16]byte, error) {
+	var u [16]byte
+	if err := g.read(u[6:]); err != nil {
+		return u, err
+	}
+	putMillis(u[:], g.millis())
+	u[6] = u[6]&0x0f | 0x70
+	u[8] = u[8]&0x3f | 0x80
+	return u, nil
+}
+
+// ULID returns a ULID carrying the current Unix millisecond timestamp
+// followed by 80 random bits.
+func (g *Generator) ULID() ([16]byte, error) {
+	var id [16]byte
+	if err := g.read(id[6:]); err != nil {
+		return id, err
+	}
+	putMillis(id[:], g.millis())
+	return id, nil
+}
+
+func (g *Generator) read(b []byte) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	_, err := io.ReadFull(g.rand, b)
+	return err
+}
+
+func (g *Generator) millis() uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return uint64(g.now().UnixMilli())
+}
+
+// putMillis stores the low 48 bits of ms big-endian in b[0:6].
+func putMillis(b []byte, ms uint64) {
+	b[0] = byte(ms >> 40)
+	b[1] = byte(ms >> 32)
+	b[2] = byte(ms >> 24)
+	b[3] = byte(ms >> 16)
+	b[4] = byte(ms >> 8)
+	b[5] = byte(ms)
+}
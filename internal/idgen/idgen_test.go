@@ -0,0 +1,65 @@
+package idgen
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestGenerator_VersionAndVariant(t *testing.T) {
+	t.Parallel()
+
+	g := New(nil, nil)
+	for name, tc := range map[string]struct {
+		gen     func() ([16]byte, error)
+		version byte
+	}{
+		"v1": {g.V1, 1},
+		"v4": {g.V4, 4},
+		"v7": {g.V7, 7},
+	} {
+		u, err := tc.gen()
+		if err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+		if got := u[6] >> 4; got != tc.version {
+			t.Fatalf("%s: expected version %d, got %d", name, tc.version, got)
+		}
+		if u[8]&0xc0 != 0x80 {
+			t.Fatalf("%s: expected RFC 4122 variant, got %x", name, u[8])
+		}
+	}
+}
+
+func TestGenerator_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	now := func() time.Time { return time.UnixMilli(1731632400000) }
+	a := New(rand.NewChaCha8([32]byte{1}), now)
+	b := New(rand.NewChaCha8([32]byte{1}), now)
+
+	for i := 0; i < 3; i++ {
+		x, _ := a.ULID()
+		y, _ := b.ULID()
+		if x != y {
+			t.Fatalf("expected identical sequences, got %x and %x", x, y)
+		}
+	}
+
+	id, _ := a.V7()
+	if ms := uint64(id[0])<<40 | uint64(id[1])<<32 | uint64(id[2])<<24 | uint64(id[3])<<16 | uint64(id[4])<<8 | uint64(id[5]); ms != 1731632400000 {
+		t.Fatalf("unexpected timestamp %d", ms)
+	}
+}
+
+func TestGenerator_V1Monotonic(t *testing.T) {
+	t.Parallel()
+
+	fixed := time.Unix(1731632400, 0)
+	g := New(nil, func() time.Time { return fixed })
+	first, _ := g.V1()
+	second, _ := g.V1()
+	if first == second {
+		t.Fatal("expected distinct v1 values for the same instant")
+	}
+}
@@ -0,0 +1,174 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+// BodyLogPolicy transforms a response body snippet before it is logged.
+type BodyLogPolicy func(body string) string
+
+// RedactBody replaces the body with its length. It is the default policy.
+func RedactBody(body string) string {
+	return fmt.Sprintf("[redacted %d bytes]", len(body))
+}
+
+// TruncateBody keeps at most n bytes of the body, cutting on a rune
+// boundary. A negative n is treated as 0.
+func TruncateBody(n int) BodyLogPolicy {
+	n = max(n, 0)
+	return func(body string) string {
+		if len(body) <= n {
+			return body
+		}
+		i := n
+		for i > 0 && !utf8.RuneStart(body[i]) {
+			i--
+		}
+		return body[:i] + "…"
+	}
+}
+
+// WithLogger logs API activity to logger: every HTTP attempt with its query
+// parameters, duration and status at Debug, retries at Info, fallbacks at
+// Warn, and failed calls at Warn or, for decode and validation failures,
+// Error. Request headers are never logged, and error response bodies pass
+// through the policy set with WithBodyLogPolicy, RedactBody by default.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		if logger == nil {
+			return errors.New("logger is nil")
+		}
+		t := transportFor(c)
+		if t.logger == nil {
+			t.observers = append(t.observers, logObserver{t: t})
+		}
+		t.logger = logger
+		return nil
+	}
+}
+
+// WithBodyLogPolicy sets how response body snippets appear in logs written
+// by WithLogger.
+func WithBodyLogPolicy(policy BodyLogPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy == nil {
+			return errors.New("body log policy is nil")
+		}
+		transportFor(c).bodyPolicy = policy
+		return nil
+	}
+}
+
+func (t *transport) logBody(body string) string {
+	if t.bodyPolicy == nil {
+		return RedactBody(body)
+	}
+	return t.bodyPolicy(body)
+}
+
+type attemptKey struct{}
+
+// withAttempt records the 1-based attempt number of req for logging.
+func withAttempt(req *http.Request, attempt int) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), attemptKey{}, attempt))
+}
+
+// logAttempt sends req through send and logs the outcome at Debug.
+func (t *transport) logAttempt(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	ctx := req.Context()
+	attempt, _ := ctx.Value(attemptKey{}).(int)
+	if attempt == 0 {
+		attempt = 1
+	}
+
+	start := time.Now()
+	resp, err := send(req)
+	attrs := []slog.Attr{
+		slog.String("query", req.URL.RawQuery),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", t.redact(err.Error())))
+	} else {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	t.logger.LogAttrs(ctx, slog.LevelDebug, "uuidify attempt", attrs...)
+	return resp, err
+}
+
+func (t *transport) logRetry(ctx context.Context, attempt int, delay time.Duration, resp *http.Response, err error) {
+	if t.logger == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.Int("attempt", attempt),
+		slog.Duration("delay", delay),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", t.redact(err.Error())))
+	} else {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	t.logger.LogAttrs(ctx, slog.LevelInfo, "uuidify retrying", attrs...)
+}
+
+// logObserver logs the outcome of every API call.
+type logObserver struct {
+	t *transport
+}
+
+func (o logObserver) CallStart(ctx context.Context, info CallInfo) context.Context {
+	return ctx
+}
+
+func (o logObserver) CallEnd(ctx context.Context, info CallInfo) {
+	attrs := []slog.Attr{
+		slog.String("version", string(info.Version)),
+		slog.Int("count", info.Count),
+		slog.Duration("duration", info.Duration),
+	}
+	if info.StatusCode != 0 {
+		attrs = append(attrs, slog.Int("status", info.StatusCode))
+	}
+	if info.Format != "" {
+		attrs = append(attrs, slog.String("format", string(info.Format)))
+	}
+
+	level := slog.LevelDebug
+	msg := "uuidify call"
+	if info.Err != nil {
+		level = slog.LevelWarn
+		msg = "uuidify call failed"
+
+		var (
+			apiErr *APIError
+			decErr *DecodeError
+			valErr *ValidationError
+		)
+		switch {
+		case errors.As(info.Err, &apiErr):
+			attrs = append(attrs, slog.String("body", o.t.logBody(apiErr.Message)))
+		case errors.As(info.Err, &decErr):
+			level = slog.LevelError
+			attrs = append(attrs, slog.String("error", o.t.redact(decErr.Error())))
+		case errors.As(info.Err, &valErr):
+			level = slog.LevelError
+			attrs = append(attrs, slog.String("error", o.t.redact(valErr.Error())))
+		default:
+			attrs = append(attrs, slog.String("error", o.t.redact(info.Err.Error())))
+		}
+
+		if info.Local {
+			level = slog.LevelWarn
+			msg = "uuidify call served by local fallback"
+		}
+	}
+	o.t.logger.LogAttrs(ctx, level, msg, attrs...)
+}
@@ -0,0 +1,154 @@
+package uuidify
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLogger_AttemptsAndRetries(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"uuid":"550e8400-e29b-41d4-a716-446655440000"}`)
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	c, err := NewClient(ts.URL,
+		WithHTTPClient(ts.Client()),
+		WithRetryPolicy(RetryPolicy{InitialBackoff: time.Millisecond}),
+		WithLogger(newBufferLogger(&buf)),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := c.UUIDv4(context.Background()); err != nil {
+		t.Fatalf("UUIDv4 returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		`msg="uuidify attempt" query="version=v4" attempt=1`,
+		`msg="uuidify retrying" attempt=1`,
+		`msg="uuidify attempt" query="version=v4" attempt=2`,
+		`level=DEBUG msg="uuidify call" version=v4 count=1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected log to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestLogger_BodyPolicy(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"error":"Invalid version parameter"}`)
+	}))
+	defer ts.Close()
+
+	cases := map[string]struct {
+		opts []ClientOption
+		want string
+	}{
+		"redacted by default": {want: `body="[redacted 37 bytes]"`},
+		"truncated": {
+			opts: []ClientOption{WithBodyLogPolicy(TruncateBody(9))},
+			want: `body="{\"error\":…"`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := append([]ClientOption{WithHTTPClient(ts.Client()), WithLogger(newBufferLogger(&buf))}, tc.opts...)
+			c, err := NewClient(ts.URL, opts...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			if _, err := c.UUIDv4(context.Background()); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			out := buf.String()
+			if !strings.Contains(out, `level=WARN msg="uuidify call failed"`) || !strings.Contains(out, tc.want) {
+				t.Fatalf("expected log to contain %q, got:\n%s", tc.want, out)
+			}
+			if strings.Contains(out, "Invalid version parameter") && name == "redacted by default" {
+				t.Fatalf("expected body to be redacted, got:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestTruncateBody(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		n          int
+		body, want string
+	}{
+		{9, `{"error":"x"}`, `{"error":…`},
+		{20, `{"error":"x"}`, `{"error":"x"}`},
+		{0, "abc", "…"},
+		{-1, "abc", "…"},
+		{-1, "", ""},
+		{2, "héllo", "h…"},
+		{3, "héllo", "hé…"},
+	}
+	for _, tc := range cases {
+		if got := TruncateBody(tc.n)(tc.body); got != tc.want {
+			t.Fatalf("TruncateBody(%d)(%q) = %q, want %q", tc.n, tc.body, got, tc.want)
+		}
+	}
+}
+
+func TestLogger_DecodeFailure(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"uuid":`)
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	c, err := NewClient(ts.URL, WithHTTPClient(ts.Client()), WithLogger(newBufferLogger(&buf)))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := c.UUIDv4(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if out := buf.String(); !strings.Contains(out, `level=ERROR msg="uuidify call failed"`) {
+		t.Fatalf("expected decode failure at error level, got:\n%s", out)
+	}
+}
+
+func newBufferLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
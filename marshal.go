@@ -0,0 +1,185 @@
+package uuidify
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ParseUUIDLenient decodes s like ParseUUID but also accepts uppercase hex
+// digits, surrounding braces and a urn:uuid: prefix, as in
+// {550E8400-E29B-41D4-A716-446655440000} or
+// urn:uuid:550e8400-e29b-41d4-a716-446655440000.
+func ParseUUIDLenient(s string) (UUID, error) {
+	t := s
+	if len(t) >= 9 && strings.EqualFold(t[:9], "urn:uuid:") {
+		t = t[9:]
+	} else if len(t) >= 2 && t[0] == '{' && t[len(t)-1] == '}' {
+		t = t[1 : len(t)-1]
+	}
+	u, err := ParseUUID(strings.ToLower(t))
+	if err != nil {
+		return UUID{}, fmt.Errorf("invalid UUID %q", s)
+	}
+	return u, nil
+}
+
+// ParseULIDLenient decodes s like ParseULID but also accepts lowercase
+// characters.
+func ParseULIDLenient(s string) (ULID, error) {
+	id, err := ParseULID(strings.ToUpper(s))
+	if err != nil {
+		return ULID{}, fmt.Errorf("invalid ULID %q", s)
+	}
+	return id, nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the canonical form.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Like ParseUUID it only
+// accepts the canonical form; use ParseUUIDLenient for other spellings.
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding u as a JSON string.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It requires a JSON string in
+// canonical form; null leaves u unchanged.
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	s, null, err := unmarshalJSONString(data, "UUID")
+	if err != nil || null {
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, returning the 16 raw
+// bytes of u.
+func (u UUID) MarshalBinary() ([]byte, error) {
+	return u.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. data must be
+// exactly 16 bytes.
+func (u *UUID) UnmarshalBinary(data []byte) error {
+	if len(data) != len(u) {
+		return fmt.Errorf("invalid UUID: length must be 16 bytes, got %d", len(data))
+	}
+	copy(u[:], data)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the 26 character form.
+func (id ULID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Like ParseULID it only
+// accepts uppercase input; use ParseULIDLenient for lowercase.
+func (id *ULID) UnmarshalText(text []byte) error {
+	parsed, err := ParseULID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding id as a JSON string.
+func (id ULID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It requires a JSON string in
+// canonical form; null leaves id unchanged.
+func (id *ULID) UnmarshalJSON(data []byte) error {
+	s, null, err := unmarshalJSONString(data, "ULID")
+	if err != nil || null {
+		return err
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, returning the 16 raw
+// bytes of id.
+func (id ULID) MarshalBinary() ([]byte, error) {
+	return id.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. data must be
+// exactly 16 bytes.
+func (id *ULID) UnmarshalBinary(data []byte) error {
+	if len(data) != len(id) {
+		return fmt.Errorf("invalid ULID: length must be 16 bytes, got %d", len(data))
+	}
+	copy(id[:], data)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding an invalid NullUUID as
+// null.
+func (n NullUUID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.UUID.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, treating null as NULL.
+func (n *NullUUID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		n.UUID, n.Valid = UUID{}, false
+		return nil
+	}
+	if err := n.UUID.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding an invalid NullULID as
+// null.
+func (n NullULID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.ULID.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, treating null as NULL.
+func (n *NullULID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		n.ULID, n.Valid = ULID{}, false
+		return nil
+	}
+	if err := n.ULID.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// unmarshalJSONString decodes a JSON string, reporting null separately.
+func unmarshalJSONString(data []byte, kind string) (string, bool, error) {
+	if string(data) == "null" {
+		return "", true, nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", false, fmt.Errorf("invalid %s: must be a JSON string", kind)
+	}
+	return s, false, nil
+}
@@ -0,0 +1,141 @@
+package uuidify
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestParseUUIDLenient(t *testing.T) {
+	t.Parallel()
+
+	want := MustParseUUID("550e8400-e29b-41d4-a716-446655440000")
+	for _, s := range []string{
+		"550e8400-e29b-41d4-a716-446655440000",
+		"550E8400-E29B-41D4-A716-446655440000",
+		"{550e8400-e29b-41d4-a716-446655440000}",
+		"urn:uuid:550e8400-e29b-41d4-a716-446655440000",
+		"URN:UUID:550E8400-E29B-41D4-A716-446655440000",
+	} {
+		got, err := ParseUUIDLenient(s)
+		if err != nil {
+			t.Fatalf("ParseUUIDLenient(%q) returned error: %v", s, err)
+		}
+		if got != want {
+			t.Fatalf("ParseUUIDLenient(%q) = %s, want %s", s, got, want)
+		}
+	}
+
+	for _, s := range []string{
+		"{550e8400-e29b-41d4-a716-446655440000",
+		"urn:uuid:{550e8400-e29b-41d4-a716-446655440000}",
+		"550e8400e29b41d4a716446655440000",
+		"",
+	} {
+		if _, err := ParseUUIDLenient(s); err == nil {
+			t.Fatalf("expected error for %q", s)
+		}
+	}
+}
+
+func TestParseULIDLenient(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseULIDLenient("01arz3ndektsv4rrffq69g5fav")
+	if err != nil {
+		t.Fatalf("ParseULIDLenient returned error: %v", err)
+	}
+	if got != MustParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV") {
+		t.Fatalf("unexpected ULID %s", got)
+	}
+}
+
+func TestUUID_JSON(t *testing.T) {
+	t.Parallel()
+
+	type doc struct {
+		ID     UUID     `json:"id"`
+		Parent NullUUID `json:"parent"`
+		Owner  ULID     `json:"owner"`
+		Group  NullULID `json:"group"`
+	}
+	in := doc{
+		ID:    MustParseUUID("550e8400-e29b-41d4-a716-446655440000"),
+		Owner: MustParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV"),
+		Group: NullULID{ULID: MustParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV"), Valid: true},
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	const want = `{"id":"550e8400-e29b-41d4-a716-446655440000","parent":null,"owner":"01ARZ3NDEKTSV4RRFFQ69G5FAV","group":"01ARZ3NDEKTSV4RRFFQ69G5FAV"}`
+	if string(data) != want {
+		t.Fatalf("expected %s, got %s", want, data)
+	}
+
+	var out doc
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if out != in {
+		t.Fatalf("expected %+v, got %+v", in, out)
+	}
+}
+
+func TestUUID_UnmarshalStrict(t *testing.T) {
+	t.Parallel()
+
+	for _, data := range []string{
+		`"550E8400-E29B-41D4-A716-446655440000"`,
+		`"{550e8400-e29b-41d4-a716-446655440000}"`,
+		`"urn:uuid:550e8400-e29b-41d4-a716-446655440000"`,
+		`42`,
+	} {
+		var u UUID
+		if err := json.Unmarshal([]byte(data), &u); err == nil {
+			t.Fatalf("expected error for %s", data)
+		}
+	}
+
+	var u UUID
+	if err := u.UnmarshalText([]byte("550e8400-e29b-41d4-a716-446655440000")); err != nil {
+		t.Fatalf("UnmarshalText returned error: %v", err)
+	}
+	var id ULID
+	if err := id.UnmarshalText([]byte("01arz3ndektsv4rrffq69g5fav")); err == nil {
+		t.Fatal("expected error for lowercase ULID")
+	}
+}
+
+func TestUUID_Binary(t *testing.T) {
+	t.Parallel()
+
+	u := MustParseUUID("550e8400-e29b-41d4-a716-446655440000")
+	data, err := u.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary returned error: %v", err)
+	}
+	if !bytes.Equal(data, u[:]) {
+		t.Fatalf("expected raw bytes, got %x", data)
+	}
+	var back UUID
+	if err := back.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary returned error: %v", err)
+	}
+	if back != u {
+		t.Fatalf("expected %s, got %s", u, back)
+	}
+	if err := back.UnmarshalBinary(data[:15]); err == nil {
+		t.Fatal("expected error for short input")
+	}
+
+	id := MustParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	data, err = id.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary returned error: %v", err)
+	}
+	var idBack ULID
+	if err := idBack.UnmarshalBinary(data); err != nil || idBack != id {
+		t.Fatalf("expected %s, got %s (%v)", id, idBack, err)
+	}
+}
@@ -0,0 +1,89 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// CallInfo describes one API call. Observer.CallStart receives only Version
+// and Count; the remaining fields are filled in for Observer.CallEnd.
+type CallInfo struct {
+	Version GetParamsVersion
+	Count   int
+
+	// Format is the format of the response body, if one was decoded.
+	Format GetParamsFormat
+	// StatusCode is the HTTP status of the response, or 0 if none arrived.
+	StatusCode int
+	Duration   time.Duration
+	// IDs is the number of identifiers handed back to the caller.
+	IDs int
+	// Local reports whether the identifiers came from WithLocalFallback, in
+	// which case Err holds the API error that triggered it.
+	Local bool
+	// Err is the *RequestError, *APIError, *DecodeError or *ValidationError
+	// the call failed with.
+	Err error
+}
+
+// Observer is notified around every API call made through the SDK's fetch
+// methods. Implementations must be safe for concurrent use.
+type Observer interface {
+	// CallStart is invoked before the request is sent. The returned context
+	// is used for the call and passed to CallEnd, so observers can attach
+	// spans or other values that request editors may read.
+	CallStart(ctx context.Context, info CallInfo) context.Context
+
+	// CallEnd is invoked once the call has completed.
+	CallEnd(ctx context.Context, info CallInfo)
+}
+
+// WithObserver registers o to be notified around every API call. Observers
+// run in registration order for CallStart and in reverse order for CallEnd.
+func WithObserver(o Observer) ClientOption {
+	return func(c *Client) error {
+		if o == nil {
+			return errors.New("observer is nil")
+		}
+		t := transportFor(c)
+		t.observers = append(t.observers, o)
+		return nil
+	}
+}
+
+func (t *transport) observe(ctx context.Context, version GetParamsVersion, count int, fetch func(context.Context, GetParamsVersion, int) ([]string, *payload, error)) ([]string, *payload, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	info := CallInfo{Version: version, Count: count}
+	ctxs := make([]context.Context, len(t.observers))
+	for i, o := range t.observers {
+		ctx = o.CallStart(ctx, info)
+		ctxs[i] = ctx
+	}
+
+	start := time.Now()
+	ids, resp, err := fetch(ctx, version, count)
+	info.Duration = time.Since(start)
+	info.IDs = len(ids)
+	info.Err = err
+	if resp != nil {
+		info.StatusCode = resp.status
+		info.Format = resp.format
+		info.Local = resp.local
+		if resp.local {
+			info.Err = resp.cause
+		}
+	}
+	var apiErr *APIError
+	if errors.As(info.Err, &apiErr) {
+		info.StatusCode = apiErr.StatusCode
+	}
+
+	for i := len(t.observers) - 1; i >= 0; i-- {
+		t.observers[i].CallEnd(ctxs[i], info)
+	}
+	return ids, resp, err
+}
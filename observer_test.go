@@ -0,0 +1,93 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type ctxKey struct{}
+
+type recordingObserver struct {
+	mu    sync.Mutex
+	ends  []CallInfo
+	value any
+}
+
+func (o *recordingObserver) CallStart(ctx context.Context, info CallInfo) context.Context {
+	return context.WithValue(ctx, ctxKey{}, "started")
+}
+
+func (o *recordingObserver) CallEnd(ctx context.Context, info CallInfo) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.ends = append(o.ends, info)
+	o.value = ctx.Value(ctxKey{})
+}
+
+func TestObserver_Success(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := newBatchServer(t, &calls)
+	defer ts.Close()
+
+	obs := &recordingObserver{}
+	var editorSaw any
+	c, err := NewClient(ts.URL,
+		WithHTTPClient(ts.Client()),
+		WithObserver(obs),
+		WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			editorSaw = ctx.Value(ctxKey{})
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := c.UUIDBatch(context.Background(), "v4", 3); err != nil {
+		t.Fatalf("UUIDBatch returned error: %v", err)
+	}
+
+	if len(obs.ends) != 1 {
+		t.Fatalf("expected 1 call, got %d", len(obs.ends))
+	}
+	info := obs.ends[0]
+	if info.Version != GetParamsVersionV4 || info.Count != 3 || info.IDs != 3 ||
+		info.StatusCode != http.StatusOK || info.Format != Json || info.Err != nil {
+		t.Fatalf("unexpected call info %+v", info)
+	}
+	if obs.value != "started" || editorSaw != "started" {
+		t.Fatalf("expected observer context to reach editors and CallEnd, got %v and %v", editorSaw, obs.value)
+	}
+}
+
+func TestObserver_APIError(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	obs := &recordingObserver{}
+	c, err := NewClient(ts.URL, WithHTTPClient(ts.Client()), WithObserver(obs))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := c.ULID(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	info := obs.ends[0]
+	var apiErr *APIError
+	if !errors.As(info.Err, &apiErr) || info.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("unexpected call info %+v", info)
+	}
+}
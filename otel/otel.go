@@ -0,0 +1,213 @@
+// Package uuidifyotel instruments a uuidify.Client with OpenTelemetry tracing and
+// metrics.
+//
+//	client, err := uuidify.NewDefaultClient(uuidifyotel.Instrument())
+//
+// Every API call gets a client span carrying the requested version, count
+// and response format, the HTTP status code and, on failure, the error type.
+// Trace context is propagated to the API through request headers. Latency is
+// recorded in a histogram, and generated identifiers and errors are counted.
+package uuidifyotel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	uuidify "github.com/ilkereroglu/uuidify-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope used for spans and metrics.
+const ScopeName = "github.com/ilkereroglu/uuidify-go/otel"
+
+// Attribute keys set on spans and metrics.
+const (
+	AttrVersion    = attribute.Key("uuidify.version")
+	AttrCount      = attribute.Key("uuidify.count")
+	AttrFormat     = attribute.Key("uuidify.format")
+	AttrLocal      = attribute.Key("uuidify.local")
+	AttrStatusCode = attribute.Key("http.response.status_code")
+	AttrErrorType  = attribute.Key("error.type")
+)
+
+// Error types reported in the error.type attribute.
+const (
+	ErrorTypeRequest    = "request"
+	ErrorTypeAPI        = "api"
+	ErrorTypeDecode     = "decode"
+	ErrorTypeValidation = "validation"
+	ErrorTypeOther      = "other"
+)
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// WithTracerProvider sets the TracerProvider. Defaults to the global one.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the MeterProvider. Defaults to the global one.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagators sets the propagator used to inject trace context into
+// request headers. Defaults to the global one.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = p
+	}
+}
+
+// Instrument returns a ClientOption that traces and measures every API call
+// made by the client.
+func Instrument(opts ...Option) uuidify.ClientOption {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	return func(c *uuidify.Client) error {
+		obs, err := newObserver(cfg)
+		if err != nil {
+			return err
+		}
+		if err := uuidify.WithObserver(obs)(c); err != nil {
+			return err
+		}
+		return uuidify.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			cfg.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+			return nil
+		})(c)
+	}
+}
+
+type observer struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	ids      metric.Int64Counter
+	errors   metric.Int64Counter
+}
+
+func newObserver(cfg config) (*observer, error) {
+	meter := cfg.meterProvider.Meter(ScopeName)
+
+	duration, err := meter.Float64Histogram("uuidify.client.duration",
+		metric.WithDescription("Duration of UUIDify API calls."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := meter.Int64Counter("uuidify.client.ids",
+		metric.WithDescription("Number of identifiers returned to callers."),
+		metric.WithUnit("{id}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	errs, err := meter.Int64Counter("uuidify.client.errors",
+		metric.WithDescription("Number of failed UUIDify API calls by error type."),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &observer{
+		tracer:   cfg.tracerProvider.Tracer(ScopeName),
+		duration: duration,
+		ids:      ids,
+		errors:   errs,
+	}, nil
+}
+
+func (o *observer) CallStart(ctx context.Context, info uuidify.CallInfo) context.Context {
+	ctx, _ = o.tracer.Start(ctx, "uuidify.generate",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			AttrVersion.String(string(info.Version)),
+			AttrCount.Int(info.Count),
+		),
+	)
+	return ctx
+}
+
+func (o *observer) CallEnd(ctx context.Context, info uuidify.CallInfo) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	attrs := []attribute.KeyValue{AttrVersion.String(string(info.Version))}
+	if info.StatusCode != 0 {
+		attrs = append(attrs, AttrStatusCode.Int(info.StatusCode))
+	}
+	if info.Err != nil {
+		attrs = append(attrs, AttrErrorType.String(ErrorType(info.Err)))
+	}
+	if info.Local {
+		attrs = append(attrs, AttrLocal.Bool(true))
+	}
+
+	span.SetAttributes(attrs...)
+	if info.Format != "" {
+		span.SetAttributes(AttrFormat.String(string(info.Format)))
+	}
+	if info.Err != nil {
+		span.RecordError(info.Err)
+		if !info.Local {
+			span.SetStatus(codes.Error, info.Err.Error())
+		}
+		o.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+
+	o.duration.Record(ctx, info.Duration.Seconds(), metric.WithAttributes(attrs...))
+	if info.IDs > 0 {
+		o.ids.Add(ctx, int64(info.IDs), metric.WithAttributes(
+			AttrVersion.String(string(info.Version)),
+			AttrLocal.Bool(info.Local),
+		))
+	}
+}
+
+// ErrorType classifies err by the uuidify error type it wraps.
+func ErrorType(err error) string {
+	var (
+		reqErr *uuidify.RequestError
+		apiErr *uuidify.APIError
+		decErr *uuidify.DecodeError
+		valErr *uuidify.ValidationError
+	)
+	switch {
+	case errors.As(err, &apiErr):
+		return ErrorTypeAPI
+	case errors.As(err, &decErr):
+		return ErrorTypeDecode
+	case errors.As(err, &valErr):
+		return ErrorTypeValidation
+	case errors.As(err, &reqErr):
+		return ErrorTypeRequest
+	default:
+		return ErrorTypeOther
+	}
+}
@@ -0,0 +1,113 @@
+package uuidifyotel_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	uuidify "github.com/ilkereroglu/uuidify-go"
+	uuidifyotel "github.com/ilkereroglu/uuidify-go/otel"
+	"github.com/ilkereroglu/uuidify-go/uuidifytest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInstrument(t *testing.T) {
+	t.Parallel()
+
+	s := uuidifytest.NewServer()
+	defer s.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	c, err := s.NewClient(uuidifyotel.Instrument(
+		uuidifyotel.WithTracerProvider(tp),
+		uuidifyotel.WithMeterProvider(mp),
+		uuidifyotel.WithPropagators(propagation.TraceContext{}),
+	))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := c.UUIDBatch(ctx, "v7", 4); err != nil {
+		t.Fatalf("UUIDBatch returned error: %v", err)
+	}
+	s.FailNext(1, http.StatusInternalServerError)
+	if _, err := c.ULID(ctx); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(ended))
+	}
+	ok := attrs(ended[0].Attributes())
+	if ok["uuidify.version"] != "v7" || ok["uuidify.count"] != int64(4) || ok["uuidify.format"] != "json" || ok["http.response.status_code"] != int64(200) {
+		t.Fatalf("unexpected span attributes %v", ok)
+	}
+	failed := attrs(ended[1].Attributes())
+	if failed["error.type"] != uuidifyotel.ErrorTypeAPI || ended[1].Status().Code != codes.Error {
+		t.Fatalf("unexpected failed span %v %v", failed, ended[1].Status())
+	}
+
+	reqs := s.Requests()
+	if got := reqs[0].Header.Get("Traceparent"); got == "" {
+		t.Fatal("expected traceparent header to be propagated")
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	got := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			got[m.Name] = true
+			if m.Name == "uuidify.client.ids" {
+				sum := m.Data.(metricdata.Sum[int64])
+				if total := sum.DataPoints[0].Value; total != 4 {
+					t.Fatalf("expected 4 ids counted, got %d", total)
+				}
+			}
+		}
+	}
+	for _, name := range []string{"uuidify.client.duration", "uuidify.client.ids", "uuidify.client.errors"} {
+		if !got[name] {
+			t.Fatalf("expected metric %s, got %v", name, got)
+		}
+	}
+}
+
+func TestErrorType(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]error{
+		uuidifyotel.ErrorTypeRequest:    &uuidify.RequestError{},
+		uuidifyotel.ErrorTypeAPI:        &uuidify.APIError{},
+		uuidifyotel.ErrorTypeDecode:     &uuidify.DecodeError{},
+		uuidifyotel.ErrorTypeValidation: &uuidify.ValidationError{},
+		uuidifyotel.ErrorTypeOther:      context.Canceled,
+	}
+	for want, err := range cases {
+		if got := uuidifyotel.ErrorType(err); got != want {
+			t.Fatalf("ErrorType(%T) = %s, want %s", err, got, want)
+		}
+	}
+}
+
+func attrs(kvs []attribute.KeyValue) map[string]any {
+	m := make(map[string]any, len(kvs))
+	for _, kv := range kvs {
+		m[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	return m
+}
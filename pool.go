@@ -0,0 +1,232 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+const defaultPoolSize = 1000
+
+// ErrPoolClosed is returned by Pool.Next after the pool has been closed.
+var ErrPoolClosed = errors.New("uuidify: pool closed")
+
+// PoolConfig configures a Pool. Zero fields fall back to defaults.
+type PoolConfig struct {
+	// Size is the number of identifiers the pool buffers. Defaults to 1000.
+	Size int
+
+	// LowWater is the buffered count below which a background refill is
+	// started. Defaults to a quarter of Size, and at least 1.
+	LowWater int
+
+	// BatchSize is the maximum number of identifiers fetched per API call.
+	// Defaults to Size, capped at 1000.
+	BatchSize int
+}
+
+// PoolStats is a snapshot of Pool counters.
+type PoolStats struct {
+	// Hits counts Next calls served straight from the buffer.
+	Hits uint64
+	// Stalls counts Next calls that found the buffer empty and had to wait.
+	Stalls uint64
+	// Refills counts successful batch fetches.
+	Refills uint64
+	// RefillErrors counts failed batch fetches.
+	RefillErrors uint64
+	// Buffered is the number of identifiers currently held.
+	Buffered int
+}
+
+// Pool prefetches identifiers of a single version in batches and serves them
+// from memory. Create one Pool per version you need.
+type Pool struct {
+	client  *Client
+	version GetParamsVersion
+	cfg     PoolConfig
+
+	buf    chan string
+	signal chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	failed  chan struct{}
+	lastErr error
+
+	hits, stalls, refills, refillErrs atomic.Uint64
+}
+
+// NewPool starts a Pool that keeps identifiers of the given version ("v1",
+// "v4", "v7" or "ulid") buffered. Callers must Close the pool when done.
+func NewPool(client *Client, version string, cfg PoolConfig) (*Pool, error) {
+	if client == nil {
+		return nil, errors.New("client is nil")
+	}
+	ver := GetParamsVersion(version)
+	if !isSupportedUUIDVersion(ver) && ver != GetParamsVersionUlid {
+		return nil, fmt.Errorf("version must be one of v1, v4, v7, ulid")
+	}
+	if cfg.Size < 0 || cfg.LowWater < 0 || cfg.BatchSize < 0 {
+		return nil, errors.New("pool config values must not be negative")
+	}
+	if cfg.Size == 0 {
+		cfg.Size = defaultPoolSize
+	}
+	if cfg.LowWater == 0 {
+		cfg.LowWater = max(cfg.Size/4, 1)
+	}
+	if cfg.LowWater > cfg.Size {
+		cfg.LowWater = cfg.Size
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = cfg.Size
+	}
+	if cfg.BatchSize > maxBatchCount {
+		cfg.BatchSize = maxBatchCount
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		client:  client,
+		version: ver,
+		cfg:     cfg,
+		buf:     make(chan string, cfg.Size),
+		signal:  make(chan struct{}, 1),
+		ctx:     ctx,
+		cancel:  cancel,
+		failed:  make(chan struct{}),
+	}
+
+	p.wg.Add(1)
+	go p.run()
+	p.triggerRefill()
+
+	return p, nil
+}
+
+// Next returns a buffered identifier, waiting for a refill if the buffer is
+// empty. It returns the refill error if the fetch it waited on failed.
+func (p *Pool) Next(ctx context.Context) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if p.ctx.Err() != nil {
+		return "", ErrPoolClosed
+	}
+
+	select {
+	case id := <-p.buf:
+		p.hits.Add(1)
+		p.maybeRefill()
+		return id, nil
+	default:
+	}
+
+	p.stalls.Add(1)
+	p.mu.Lock()
+	failed := p.failed
+	p.mu.Unlock()
+	p.triggerRefill()
+
+	select {
+	case id := <-p.buf:
+		p.maybeRefill()
+		return id, nil
+	case <-failed:
+		p.mu.Lock()
+		err := p.lastErr
+		p.mu.Unlock()
+		return "", err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-p.ctx.Done():
+		return "", ErrPoolClosed
+	}
+}
+
+// Stats returns a snapshot of the pool counters.
+func (p *Pool) Stats() PoolStats {
+	return PoolStats{
+		Hits:         p.hits.Load(),
+		Stalls:       p.stalls.Load(),
+		Refills:      p.refills.Load(),
+		RefillErrors: p.refillErrs.Load(),
+		Buffered:     len(p.buf),
+	}
+}
+
+// Close stops background refills and waits for an in-flight fetch to finish.
+// Buffered identifiers are discarded.
+func (p *Pool) Close() error {
+	p.cancel()
+	p.wg.Wait()
+	return nil
+}
+
+func (p *Pool) maybeRefill() {
+	if len(p.buf) < p.cfg.LowWater {
+		p.triggerRefill()
+	}
+}
+
+func (p *Pool) triggerRefill() {
+	select {
+	case p.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (p *Pool) run() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-p.signal:
+		}
+		p.fill()
+	}
+}
+
+// fill fetches batches until the buffer is full or a fetch fails. Only the
+// run goroutine sends on buf, so a batch never blocks on a full buffer.
+func (p *Pool) fill() {
+	for {
+		room := cap(p.buf) - len(p.buf)
+		if room <= 0 || p.ctx.Err() != nil {
+			return
+		}
+		n := min(room, p.cfg.BatchSize)
+
+		ids, err := p.fetch(n)
+		if err != nil {
+			if p.ctx.Err() != nil {
+				return
+			}
+			p.refillErrs.Add(1)
+			p.mu.Lock()
+			p.lastErr = err
+			close(p.failed)
+			p.failed = make(chan struct{})
+			p.mu.Unlock()
+			return
+		}
+
+		p.refills.Add(1)
+		for _, id := range ids {
+			p.buf <- id
+		}
+	}
+}
+
+func (p *Pool) fetch(n int) ([]string, error) {
+	if p.version == GetParamsVersionUlid {
+		return p.client.ULIDBatch(p.ctx, n)
+	}
+	return p.client.UUIDBatch(p.ctx, string(p.version), n)
+}
@@ -0,0 +1,171 @@
+package uuidify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPool_ServesFromBuffer(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := newBatchServer(t, &calls)
+	defer ts.Close()
+
+	p, err := NewPool(newTestClient(t, ts), "v4", PoolConfig{Size: 10, LowWater: 2})
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	defer p.Close()
+
+	seen := make(map[string]bool)
+	for i := 0; i < 25; i++ {
+		id, err := p.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next returned error: %v", err)
+		}
+		if seen[id] {
+			t.Fatalf("duplicate id %s", id)
+		}
+		seen[id] = true
+	}
+
+	stats := p.Stats()
+	if stats.Hits+stats.Stalls != 25 {
+		t.Fatalf("expected 25 hits+stalls, got %+v", stats)
+	}
+	if stats.Refills < 3 {
+		t.Fatalf("expected at least 3 refills, got %+v", stats)
+	}
+}
+
+func TestPool_SmallRefillsInBackground(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := newBatchServer(t, &calls)
+	defer ts.Close()
+
+	p, err := NewPool(newTestClient(t, ts), "v4", PoolConfig{Size: 3})
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	defer p.Close()
+
+	waitFor := func(cond func(PoolStats) bool) PoolStats {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			stats := p.Stats()
+			if cond(stats) {
+				return stats
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for the pool, got %+v", stats)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	waitFor(func(s PoolStats) bool { return s.Buffered == 3 })
+	for i := 0; i < 3; i++ {
+		if _, err := p.Next(context.Background()); err != nil {
+			t.Fatalf("Next returned error: %v", err)
+		}
+	}
+	stats := waitFor(func(s PoolStats) bool { return s.Refills >= 2 && s.Buffered == 3 })
+	if stats.Stalls != 0 {
+		t.Fatalf("expected no stalls, got %+v", stats)
+	}
+}
+
+func TestPool_RefillError(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	p, err := NewPool(newTestClient(t, ts), "v7", PoolConfig{Size: 5})
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	defer p.Close()
+
+	_, err = p.Next(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if p.Stats().RefillErrors == 0 {
+		t.Fatal("expected refill errors to be counted")
+	}
+}
+
+func TestPool_Close(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := newBatchServer(t, &calls)
+	defer ts.Close()
+
+	p, err := NewPool(newTestClient(t, ts), "ulid", PoolConfig{Size: 4})
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if _, err := p.Next(context.Background()); !errors.Is(err, ErrPoolClosed) {
+		t.Fatalf("expected ErrPoolClosed, got %v", err)
+	}
+}
+
+// newBatchServer serves spec-shaped responses with distinct, valid
+// identifiers for whatever version and count are requested.
+func newBatchServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	var seq atomic.Uint64
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		q := r.URL.Query()
+		version := q.Get("version")
+		count := 1
+		if v := q.Get("count"); v != "" {
+			count, _ = strconv.Atoi(v)
+		}
+
+		ids := make([]string, count)
+		for i := range ids {
+			n := seq.Add(1)
+			if version == "ulid" {
+				var id ULID
+				id[15], id[14] = byte(n), byte(n>>8)
+				ids[i] = id.String()
+			} else {
+				ids[i] = fmt.Sprintf("00000000-0000-%s000-8000-%012x", version[1:], n)
+			}
+		}
+
+		key := "uuid"
+		if version == "ulid" {
+			key = "ulid"
+		}
+		body := map[string]any{key: ids[0]}
+		if count > 1 {
+			body = map[string]any{key + "s": ids}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(body)
+	}))
+}
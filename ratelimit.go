@@ -0,0 +1,210 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const defaultRateLimitMaxPause = 30 * time.Second
+
+// ErrRateLimited is returned, wrapped in a *RequestError, for calls rejected
+// without contacting the API because the server asked the client to pause for
+// longer than RateLimit.MaxPause.
+var ErrRateLimited = errors.New("uuidify: rate limited by server")
+
+// RateLimit configures client-side throttling with token buckets. Zero
+// rates disable the corresponding bucket.
+type RateLimit struct {
+	// RequestsPerSecond limits HTTP requests, counting every retry attempt.
+	RequestsPerSecond float64
+	// RequestBurst is the request bucket size. Defaults to 1.
+	RequestBurst int
+
+	// IDsPerSecond limits the identifiers requested, weighting each request
+	// by its count.
+	IDsPerSecond float64
+	// IDBurst is the identifier bucket size. Defaults to 1000 so that a
+	// single full batch can always proceed.
+	IDBurst int
+
+	// MaxPause is the longest pause requested by the server that is waited
+	// out. While the server asks for more, calls fail immediately with
+	// ErrRateLimited. Defaults to 30s.
+	MaxPause time.Duration
+}
+
+// WithRateLimit throttles requests according to limit, blocking until
+// tokens are available or the request context is done.
+//
+// The limiter also adapts to the server: after a 429 response, or a response
+// reporting X-RateLimit-Remaining: 0, requests are held back until the time
+// given by Retry-After, RateLimit-Reset or X-RateLimit-Reset, up to
+// limit.MaxPause.
+func WithRateLimit(limit RateLimit) ClientOption {
+	return func(c *Client) error {
+		if limit.RequestsPerSecond < 0 || limit.IDsPerSecond < 0 || limit.RequestBurst < 0 || limit.IDBurst < 0 || limit.MaxPause < 0 {
+			return errors.New("rate limit values must not be negative")
+		}
+		l := &rateLimiter{maxPause: limit.MaxPause}
+		if l.maxPause == 0 {
+			l.maxPause = defaultRateLimitMaxPause
+		}
+		if limit.RequestsPerSecond > 0 {
+			l.requests = newTokenBucket(limit.RequestsPerSecond, max(limit.RequestBurst, 1))
+		}
+		if limit.IDsPerSecond > 0 {
+			burst := limit.IDBurst
+			if burst == 0 {
+				burst = maxBatchCount
+			}
+			l.ids = newTokenBucket(limit.IDsPerSecond, burst)
+		}
+		transportFor(c).limiter = l
+		return nil
+	}
+}
+
+type rateLimiter struct {
+	requests *tokenBucket
+	ids      *tokenBucket
+	maxPause time.Duration
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// do waits for capacity, sends req and learns from the response.
+func (l *rateLimiter) do(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	ctx := req.Context()
+	if err := l.wait(ctx, requestCount(req)); err != nil {
+		return nil, err
+	}
+
+	resp, err := send(req)
+	if err == nil {
+		l.observe(resp, time.Now())
+	}
+	return resp, err
+}
+
+func (l *rateLimiter) wait(ctx context.Context, count int) error {
+	l.mu.Lock()
+	pause := time.Until(l.pausedUntil)
+	l.mu.Unlock()
+	if pause > l.maxPause {
+		return fmt.Errorf("%w for another %s", ErrRateLimited, pause.Round(time.Second))
+	}
+	if err := sleepContext(ctx, pause); err != nil {
+		return err
+	}
+
+	if l.requests != nil {
+		if err := l.requests.wait(ctx, 1); err != nil {
+			return err
+		}
+	}
+	if l.ids != nil {
+		if err := l.ids.wait(ctx, float64(count)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// observe pauses the limiter when the server signals that the quota is
+// exhausted.
+func (l *rateLimiter) observe(resp *http.Response, now time.Time) {
+	exhausted := resp.StatusCode == http.StatusTooManyRequests || resp.Header.Get("X-RateLimit-Remaining") == "0"
+	if !exhausted {
+		return
+	}
+	d, ok := rateLimitReset(resp.Header, now)
+	if !ok {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := now.Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// rateLimitReset returns how long to wait according to Retry-After,
+// RateLimit-Reset (delta seconds) or X-RateLimit-Reset (delta seconds or a
+// Unix timestamp).
+func rateLimitReset(h http.Header, now time.Time) (time.Duration, bool) {
+	if d, ok := retryAfter(h, now); ok {
+		return d, true
+	}
+	for _, key := range []string{"RateLimit-Reset", "X-RateLimit-Reset"} {
+		v, err := strconv.ParseInt(h.Get(key), 10, 64)
+		if err != nil || v < 0 {
+			continue
+		}
+		// Values this large can only be absolute Unix timestamps.
+		if v > 1e9 {
+			if d := time.Unix(v, 0).Sub(now); d > 0 {
+				return d, true
+			}
+			return 0, true
+		}
+		return time.Duration(v) * time.Second, true
+	}
+	return 0, false
+}
+
+// requestCount returns the count query parameter of req, defaulting to 1.
+func requestCount(req *http.Request) int {
+	n, err := strconv.Atoi(req.URL.Query().Get("count"))
+	if err != nil || n < 1 {
+		return 1
+	}
+	return n
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until n tokens are available and takes them. Requests larger
+// than the bucket are capped at its size so they can eventually proceed.
+func (b *tokenBucket) wait(ctx context.Context, n float64) error {
+	n = math.Min(n, b.burst)
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+		if b.tokens >= n {
+			b.tokens -= n
+			b.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((n - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
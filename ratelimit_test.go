@@ -0,0 +1,189 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimit_Requests(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := newBatchServer(t, &calls)
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, WithHTTPClient(ts.Client()), WithRateLimit(RateLimit{RequestsPerSecond: 50}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := c.UUIDv4(context.Background()); err != nil {
+			t.Fatalf("UUIDv4 returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Fatalf("expected throttling to take at least 35ms, took %v", elapsed)
+	}
+}
+
+func TestRateLimit_WeightedByCount(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := newBatchServer(t, &calls)
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, WithHTTPClient(ts.Client()), WithRateLimit(RateLimit{IDsPerSecond: 5000, IDBurst: 100}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := c.UUIDBatch(context.Background(), "v7", 100); err != nil {
+			t.Fatalf("UUIDBatch returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Fatalf("expected throttling to take at least 35ms, took %v", elapsed)
+	}
+}
+
+func TestRateLimit_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := newBatchServer(t, &calls)
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, WithHTTPClient(ts.Client()), WithRateLimit(RateLimit{RequestsPerSecond: 0.1}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := c.UUIDv4(context.Background()); err != nil {
+		t.Fatalf("UUIDv4 returned error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.UUIDv4(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 call to reach the server, got %d", got)
+	}
+}
+
+func TestRateLimit_AdaptsToServer(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1763168400, 0)
+	cases := []struct {
+		name   string
+		status int
+		header http.Header
+		want   time.Duration
+	}{
+		{"retry after", http.StatusTooManyRequests, http.Header{"Retry-After": {"2"}}, 2 * time.Second},
+		{"ratelimit reset", http.StatusTooManyRequests, http.Header{"Ratelimit-Reset": {"3"}}, 3 * time.Second},
+		{"x-ratelimit epoch", http.StatusOK, http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"1763168405"}}, 5 * time.Second},
+		{"quota left", http.StatusOK, http.Header{"X-Ratelimit-Remaining": {"7"}, "X-Ratelimit-Reset": {"9"}}, 0},
+	}
+	for _, tc := range cases {
+		l := &rateLimiter{}
+		l.observe(&http.Response{StatusCode: tc.status, Header: tc.header}, now)
+		var got time.Duration
+		if !l.pausedUntil.IsZero() {
+			got = l.pausedUntil.Sub(now)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: expected pause %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestRateLimit_PauseAboveMaxFailsFast(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL,
+		WithHTTPClient(ts.Client()),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+		WithRateLimit(RateLimit{RequestsPerSecond: 100, MaxPause: time.Minute}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var apiErr *APIError
+	if _, err := c.UUIDv4(context.Background()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 APIError, got %v", err)
+	}
+
+	start := time.Now()
+	if _, err := c.UUIDv4(context.Background()); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected to fail fast, took %v", elapsed)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 call, got %d", got)
+	}
+}
+
+func TestRateLimit_PauseIsNotAnOutage(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	var transitions atomic.Int32
+	c, err := NewClient(ts.URL,
+		WithHTTPClient(ts.Client()),
+		WithRateLimit(RateLimit{MaxPause: time.Second}),
+		WithCircuitBreaker(CircuitBreakerConfig{
+			FailureThreshold: 3,
+			OnStateChange:    func(from, to CircuitState) { transitions.Add(1) },
+		}),
+		WithLocalFallback(nil),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var apiErr *APIError
+	if _, err := c.UUIDv4(context.Background()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 APIError, got %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if id, err := c.UUIDv4(context.Background()); !errors.Is(err, ErrRateLimited) {
+			t.Fatalf("call %d: expected ErrRateLimited without fallback, got %q, %v", i, id, err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 call, got %d", got)
+	}
+	if got := transitions.Load(); got != 0 {
+		t.Fatalf("expected the circuit to stay closed, got %d transitions", got)
+	}
+}
@@ -0,0 +1,180 @@
+package uuidify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// The generated GetResponse models the 200 body as an opaque oneOf union that
+// ParseGetResponse never fills in. The accessors below decode Body directly
+// into the variant documented in openapi/openapi.yaml.
+
+// ResponseKind identifies which documented variant a successful GetResponse
+// holds.
+type ResponseKind int
+
+// Known response variants.
+const (
+	ResponseUnknown ResponseKind = iota
+	ResponseSingleUUID
+	ResponseUUIDList
+	ResponseSingleULID
+	ResponseULIDList
+	ResponseText
+)
+
+func (k ResponseKind) String() string {
+	switch k {
+	case ResponseSingleUUID:
+		return "single UUID"
+	case ResponseUUIDList:
+		return "UUID list"
+	case ResponseSingleULID:
+		return "single ULID"
+	case ResponseULIDList:
+		return "ULID list"
+	case ResponseText:
+		return "text"
+	default:
+		return "unknown"
+	}
+}
+
+// SingleUUID is the JSON body returned for a single UUID.
+type SingleUUID struct {
+	UUID        string    `json:"uuid"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// UUIDList is the JSON body returned for a batch of UUIDs.
+type UUIDList struct {
+	UUIDs       []string  `json:"uuids"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// SingleULID is the JSON body returned for a single ULID.
+type SingleULID struct {
+	ULID        string    `json:"ulid"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// ULIDList is the JSON body returned for a batch of ULIDs.
+type ULIDList struct {
+	ULIDs       []string  `json:"ulids"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// Kind reports which variant r holds. It returns an *APIError for
+// unsuccessful responses and a *DecodeError for bodies that match no
+// documented variant.
+func (r *GetResponse) Kind() (ResponseKind, error) {
+	if err := r.apiError(); err != nil {
+		return ResponseUnknown, err
+	}
+	if isTextPlain(r.contentType()) {
+		return ResponseText, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(r.Body, &fields); err != nil {
+		return ResponseUnknown, &DecodeError{Err: err}
+	}
+	for _, v := range []struct {
+		key  string
+		kind ResponseKind
+	}{
+		{"uuid", ResponseSingleUUID},
+		{"uuids", ResponseUUIDList},
+		{"ulid", ResponseSingleULID},
+		{"ulids", ResponseULIDList},
+	} {
+		if _, ok := fields[v.key]; ok {
+			return v.kind, nil
+		}
+	}
+	return ResponseUnknown, &DecodeError{Err: errors.New("response body matches no documented variant")}
+}
+
+// AsSingleUUID decodes r as a single UUID.
+func (r *GetResponse) AsSingleUUID() (SingleUUID, error) {
+	var v SingleUUID
+	err := r.decodeAs(ResponseSingleUUID, &v)
+	return v, err
+}
+
+// AsUUIDList decodes r as a batch of UUIDs.
+func (r *GetResponse) AsUUIDList() (UUIDList, error) {
+	var v UUIDList
+	err := r.decodeAs(ResponseUUIDList, &v)
+	return v, err
+}
+
+// AsSingleULID decodes r as a single ULID.
+func (r *GetResponse) AsSingleULID() (SingleULID, error) {
+	var v SingleULID
+	err := r.decodeAs(ResponseSingleULID, &v)
+	return v, err
+}
+
+// AsULIDList decodes r as a batch of ULIDs.
+func (r *GetResponse) AsULIDList() (ULIDList, error) {
+	var v ULIDList
+	err := r.decodeAs(ResponseULIDList, &v)
+	return v, err
+}
+
+// TextBody returns the identifiers of a text/plain response, one per line.
+func (r *GetResponse) TextBody() ([]string, error) {
+	kind, err := r.Kind()
+	if err != nil {
+		return nil, err
+	}
+	if kind != ResponseText {
+		return nil, fmt.Errorf("response holds %s, want %s", kind, ResponseText)
+	}
+	ids, err := scanLines(bytes.NewReader(r.Body))
+	if err != nil {
+		return nil, &DecodeError{Err: err}
+	}
+	return ids, nil
+}
+
+func (r *GetResponse) decodeAs(want ResponseKind, v any) error {
+	kind, err := r.Kind()
+	if err != nil {
+		return err
+	}
+	if kind != want {
+		return fmt.Errorf("response holds %s, want %s", kind, want)
+	}
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		return &DecodeError{Err: err}
+	}
+	return nil
+}
+
+// apiError returns an *APIError unless r is a 2xx response.
+func (r *GetResponse) apiError() error {
+	status := r.StatusCode()
+	if status >= http.StatusOK && status < http.StatusMultipleChoices {
+		return nil
+	}
+	msg := http.StatusText(status)
+	if r.JSON400 != nil && r.JSON400.Error != nil {
+		msg = *r.JSON400.Error
+	} else if snippet := readBodySnippet(bytes.NewReader(r.Body)); snippet != "" {
+		msg = snippet
+	}
+	return &APIError{StatusCode: status, Message: msg}
+}
+
+func (r *GetResponse) contentType() string {
+	if r.HTTPResponse == nil {
+		return ""
+	}
+	return r.HTTPResponse.Header.Get("Content-Type")
+}
@@ -0,0 +1,131 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetResponse_Variants(t *testing.T) {
+	t.Parallel()
+
+	generatedAt := time.Date(2025, 11, 15, 1, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name string
+		body string
+		want ResponseKind
+	}{
+		{"single uuid", `{"uuid":"550e8400-e29b-41d4-a716-446655440000","generated_at":"2025-11-15T01:00:00Z"}`, ResponseSingleUUID},
+		{"uuid list", `{"uuids":["550e8400-e29b-41d4-a716-446655440000","6ba7b810-9dad-11d1-80b4-00c04fd430c8"],"generated_at":"2025-11-15T01:00:00Z"}`, ResponseUUIDList},
+		{"single ulid", `{"ulid":"01HX7D9PMV4NQVP3J8B1R6R6FZ","generated_at":"2025-11-15T01:00:00Z"}`, ResponseSingleULID},
+		{"ulid list", `{"ulids":["01HX7D9PMV4NQVP3J8B1R6R6FZ","01HX7D9PMV4NQVP3J8B1R6R6GA"],"generated_at":"2025-11-15T01:00:00Z"}`, ResponseULIDList},
+	}
+	for _, tc := range cases {
+		resp := getWithResponse(t, http.StatusOK, "application/json", tc.body)
+
+		kind, err := resp.Kind()
+		if err != nil {
+			t.Fatalf("%s: Kind returned error: %v", tc.name, err)
+		}
+		if kind != tc.want {
+			t.Fatalf("%s: expected %s, got %s", tc.name, tc.want, kind)
+		}
+
+		var got time.Time
+		switch kind {
+		case ResponseSingleUUID:
+			v, err := resp.AsSingleUUID()
+			if err != nil || v.UUID != "550e8400-e29b-41d4-a716-446655440000" {
+				t.Fatalf("%s: unexpected %+v (%v)", tc.name, v, err)
+			}
+			got = v.GeneratedAt
+		case ResponseUUIDList:
+			v, err := resp.AsUUIDList()
+			if err != nil || len(v.UUIDs) != 2 {
+				t.Fatalf("%s: unexpected %+v (%v)", tc.name, v, err)
+			}
+			got = v.GeneratedAt
+		case ResponseSingleULID:
+			v, err := resp.AsSingleULID()
+			if err != nil || v.ULID != "01HX7D9PMV4NQVP3J8B1R6R6FZ" {
+				t.Fatalf("%s: unexpected %+v (%v)", tc.name, v, err)
+			}
+			got = v.GeneratedAt
+		case ResponseULIDList:
+			v, err := resp.AsULIDList()
+			if err != nil || len(v.ULIDs) != 2 {
+				t.Fatalf("%s: unexpected %+v (%v)", tc.name, v, err)
+			}
+			got = v.GeneratedAt
+		}
+		if !got.Equal(generatedAt) {
+			t.Fatalf("%s: expected generated_at %s, got %s", tc.name, generatedAt, got)
+		}
+
+		if tc.want != ResponseSingleUUID {
+			if _, err := resp.AsSingleUUID(); err == nil {
+				t.Fatalf("%s: expected AsSingleUUID to fail", tc.name)
+			}
+		}
+	}
+}
+
+func TestGetResponse_TextBody(t *testing.T) {
+	t.Parallel()
+
+	resp := getWithResponse(t, http.StatusOK, "text/plain; charset=utf-8",
+		"550e8400-e29b-41d4-a716-446655440000\n6ba7b810-9dad-11d1-80b4-00c04fd430c8\n")
+
+	ids, err := resp.TextBody()
+	if err != nil {
+		t.Fatalf("TextBody returned error: %v", err)
+	}
+	if len(ids) != 2 || ids[1] != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Fatalf("unexpected ids %v", ids)
+	}
+	if _, err := resp.AsUUIDList(); err == nil {
+		t.Fatal("expected AsUUIDList to fail for a text response")
+	}
+}
+
+func TestGetResponse_Errors(t *testing.T) {
+	t.Parallel()
+
+	resp := getWithResponse(t, http.StatusBadRequest, "application/json", `{"error":"Invalid version parameter"}`)
+	_, err := resp.AsSingleUUID()
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Message != "Invalid version parameter" {
+		t.Fatalf("expected 400 APIError, got %v", err)
+	}
+
+	resp = getWithResponse(t, http.StatusOK, "application/json", `{"id":"x"}`)
+	_, err = resp.Kind()
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected DecodeError, got %v", err)
+	}
+}
+
+func getWithResponse(t *testing.T, status int, contentType, body string) *GetResponse {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	c, err := NewClientWithResponses(ts.URL, WithHTTPClient(ts.Client()))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	resp, err := c.GetWithResponse(context.Background(), &GetParams{})
+	if err != nil {
+		t.Fatalf("GetWithResponse returned error: %v", err)
+	}
+	return resp
+}
@@ -0,0 +1,81 @@
+package uuidify
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// UUIDResult holds UUIDs together with the provenance reported by the server.
+type UUIDResult struct {
+	IDs     []UUID
+	Version GetParamsVersion
+	// GeneratedAt is the server's generated_at timestamp. It is zero when
+	// the response did not carry one, e.g. for text responses.
+	GeneratedAt time.Time
+	// Local reports whether the IDs were generated in-process by the
+	// fallback configured with WithLocalFallback.
+	Local bool
+}
+
+// ULIDResult holds ULIDs together with the provenance reported by the server.
+type ULIDResult struct {
+	IDs []ULID
+	// GeneratedAt is the server's generated_at timestamp. It is zero when
+	// the response did not carry one, e.g. for text responses.
+	GeneratedAt time.Time
+	// Local reports whether the IDs were generated in-process by the
+	// fallback configured with WithLocalFallback.
+	Local bool
+}
+
+// GenerateUUIDs fetches count UUIDs of the given version along with the
+// server's generation timestamp.
+func (c *Client) GenerateUUIDs(ctx context.Context, version string, count int) (*UUIDResult, error) {
+	ver := GetParamsVersion(version)
+	if !isSupportedUUIDVersion(ver) {
+		return nil, fmt.Errorf("version must be one of v1, v4, v7")
+	}
+	if count <= 0 || count > maxBatchCount {
+		return nil, fmt.Errorf("count must be between 1 and 1000")
+	}
+
+	raw, resp, err := c.fetch(ctx, ver, count)
+	if err != nil {
+		return nil, err
+	}
+	generatedAt, err := resp.generatedAt()
+	if err != nil {
+		return nil, &DecodeError{Err: err}
+	}
+
+	ids := make([]UUID, len(raw))
+	for i, s := range raw {
+		// fetch already validated every identifier.
+		ids[i] = MustParseUUID(s)
+	}
+	return &UUIDResult{IDs: ids, Version: ver, GeneratedAt: generatedAt, Local: resp.local}, nil
+}
+
+// GenerateULIDs fetches count ULIDs along with the server's generation
+// timestamp.
+func (c *Client) GenerateULIDs(ctx context.Context, count int) (*ULIDResult, error) {
+	if count <= 0 || count > maxBatchCount {
+		return nil, fmt.Errorf("count must be between 1 and 1000")
+	}
+
+	raw, resp, err := c.fetch(ctx, GetParamsVersionUlid, count)
+	if err != nil {
+		return nil, err
+	}
+	generatedAt, err := resp.generatedAt()
+	if err != nil {
+		return nil, &DecodeError{Err: err}
+	}
+
+	ids := make([]ULID, len(raw))
+	for i, s := range raw {
+		ids[i] = MustParseULID(s)
+	}
+	return &ULIDResult{IDs: ids, GeneratedAt: generatedAt, Local: resp.local}, nil
+}
@@ -0,0 +1,78 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGenerateUUIDs_GeneratedAt(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"uuids":[
+			"01890a5d-ac96-774b-bcce-b302099a8057",
+			"01890a5d-ac96-774b-bcce-b302099a8058"
+		],"generated_at":"2025-11-15T01:00:00Z"}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+
+	res, err := c.GenerateUUIDs(context.Background(), "v7", 2)
+	if err != nil {
+		t.Fatalf("GenerateUUIDs returned error: %v", err)
+	}
+	if len(res.IDs) != 2 || res.Version != GetParamsVersionV7 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	if want := time.Date(2025, 11, 15, 1, 0, 0, 0, time.UTC); !res.GeneratedAt.Equal(want) {
+		t.Fatalf("expected generated_at %v, got %v", want, res.GeneratedAt)
+	}
+}
+
+func TestGenerateULIDs_Single(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"ulid":"01HX7D9PMV4NQVP3J8B1R6R6FZ","generated_at":"2025-11-15T01:00:00.5Z"}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+
+	res, err := c.GenerateULIDs(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GenerateULIDs returned error: %v", err)
+	}
+	if len(res.IDs) != 1 || res.IDs[0].String() != "01HX7D9PMV4NQVP3J8B1R6R6FZ" {
+		t.Fatalf("unexpected ids %v", res.IDs)
+	}
+	if res.GeneratedAt.Nanosecond() != 500_000_000 {
+		t.Fatalf("unexpected generated_at %v", res.GeneratedAt)
+	}
+}
+
+func TestGenerateULIDs_BadTimestamp(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"ulid":"01HX7D9PMV4NQVP3J8B1R6R6FZ","generated_at":"yesterday"}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+
+	_, err := c.GenerateULIDs(context.Background(), 1)
+	var decErr *DecodeError
+	if !errors.As(err, &decErr) {
+		t.Fatalf("expected DecodeError, got %T", err)
+	}
+}
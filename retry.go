@@ -0,0 +1,199 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
+	defaultRetryMultiplier     = 2.0
+	defaultRetryMaxRetryAfter  = 30 * time.Second
+)
+
+// RetryPolicy configures how failed API calls are retried.
+//
+// Transport errors, 429 Too Many Requests and 5xx responses are retried;
+// every other status, including 400 validation errors, is returned as is.
+// Zero fields fall back to sensible defaults.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Defaults to 3.
+	MaxAttempts int
+
+	// InitialBackoff is the upper bound of the first backoff delay.
+	// Defaults to 100ms.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the exponential backoff delay. Defaults to 5s.
+	MaxBackoff time.Duration
+
+	// Multiplier is the exponential growth factor. Defaults to 2.
+	Multiplier float64
+
+	// IgnoreRetryAfter disables honoring the Retry-After response header.
+	IgnoreRetryAfter bool
+
+	// MaxRetryAfter is the longest Retry-After delay that is waited out.
+	// When the server asks for more, the response is returned without
+	// further attempts. Defaults to 30s.
+	MaxRetryAfter time.Duration
+}
+
+// WithRetryPolicy retries failed requests with exponential backoff and full
+// jitter according to policy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy.MaxAttempts < 0 || policy.InitialBackoff < 0 || policy.MaxBackoff < 0 || policy.Multiplier < 0 || policy.MaxRetryAfter < 0 {
+			return errors.New("retry policy values must not be negative")
+		}
+		p := policy.withDefaults()
+		transportFor(c).retry = &p
+		return nil
+	}
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if p.InitialBackoff == 0 {
+		p.InitialBackoff = defaultRetryInitialBackoff
+	}
+	if p.MaxBackoff == 0 {
+		p.MaxBackoff = defaultRetryMaxBackoff
+	}
+	if p.Multiplier == 0 {
+		p.Multiplier = defaultRetryMultiplier
+	}
+	if p.MaxRetryAfter == 0 {
+		p.MaxRetryAfter = defaultRetryMaxRetryAfter
+	}
+	return p
+}
+
+// do sends req until it succeeds, fails permanently or runs out of attempts,
+// calling onRetry before each backoff.
+func (p *RetryPolicy) do(req *http.Request, send func(*http.Request) (*http.Response, error), onRetry func(context.Context, int, time.Duration, *http.Response, error)) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		attemptReq, err := cloneRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		attemptReq = withAttempt(attemptReq, attempt)
+
+		resp, err := send(attemptReq)
+		if attempt >= p.MaxAttempts || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay := p.backoff(attempt)
+		if resp != nil {
+			if ra, ok := retryAfter(resp.Header, time.Now()); ok && !p.IgnoreRetryAfter {
+				if ra > p.MaxRetryAfter {
+					return resp, nil
+				}
+				delay = ra
+			}
+		}
+		onRetry(ctx, attempt, delay, resp, err)
+		if resp != nil {
+			drainAndClose(resp.Body)
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns a full-jitter delay for the given 1-based attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	ceiling := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if ceiling > float64(p.MaxBackoff) || math.IsInf(ceiling, 0) || math.IsNaN(ceiling) {
+		ceiling = float64(p.MaxBackoff)
+	}
+	if ceiling < 1 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(ceiling)))
+}
+
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, ErrRateLimited)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an
+// HTTP date.
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		if secs > math.MaxInt64/int(time.Second) {
+			return math.MaxInt64, true
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// cloneRequest prepares req for another attempt, rewinding its body if any.
+func cloneRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+func drainAndClose(body io.ReadCloser) {
+	if body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, 4096))
+	_ = body.Close()
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,219 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetry_ServerErrorThenSuccess(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"uuid":"550e8400-e29b-41d4-a716-446655440000"}`)
+	}))
+	defer ts.Close()
+
+	c := newRetryTestClient(t, ts, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	if _, err := c.UUIDv4(context.Background()); err != nil {
+		t.Fatalf("UUIDv4 returned error: %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("expected 3 calls, got %d", got)
+	}
+}
+
+func TestRetry_Exhausted(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	c := newRetryTestClient(t, ts, RetryPolicy{MaxAttempts: 4, InitialBackoff: time.Millisecond})
+
+	_, err := c.UUIDv4(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 APIError, got %v", err)
+	}
+	if got := calls.Load(); got != 4 {
+		t.Fatalf("expected 4 calls, got %d", got)
+	}
+}
+
+func TestRetry_NoRetryOnBadRequest(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"error":"Invalid version parameter"}`)
+	}))
+	defer ts.Close()
+
+	c := newRetryTestClient(t, ts, RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond})
+
+	if _, err := c.UUIDv4(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 call, got %d", got)
+	}
+}
+
+func TestRetry_TransportError(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return nil, errors.New("boom")
+	})}
+
+	c, err := NewClient("https://example.com",
+		WithHTTPClient(client),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = c.UUIDv4(context.Background())
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected RequestError, got %T", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestRetry_ContextCanceledDuringBackoff(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "20")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := newRetryTestClient(t, ts, RetryPolicy{MaxAttempts: 3})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.UUIDv4(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestRetry_RetryAfterAboveMax(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := newRetryTestClient(t, ts, RetryPolicy{MaxAttempts: 3})
+
+	start := time.Now()
+	_, err := c.UUIDv4(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 APIError, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 call, got %d", got)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected to give up at once, took %v", elapsed)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 11, 15, 1, 0, 0, 0, time.UTC)
+	cases := map[string]time.Duration{
+		"3":                             3 * time.Second,
+		"Sat, 15 Nov 2025 01:00:05 GMT": 5 * time.Second,
+		"99999999999999999":             math.MaxInt64,
+	}
+	for v, want := range cases {
+		h := http.Header{}
+		h.Set("Retry-After", v)
+		got, ok := retryAfter(h, now)
+		if !ok || got != want {
+			t.Fatalf("retryAfter(%q) = %v, %v; want %v", v, got, ok, want)
+		}
+	}
+	if _, ok := retryAfter(http.Header{}, now); ok {
+		t.Fatal("expected no Retry-After")
+	}
+}
+
+func TestRetry_PolicyBeforeWithHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	orders := map[string][]ClientOption{
+		"last":   {WithRetryPolicy(policy), WithHTTPClient(ts.Client())},
+		"middle": {WithRetryPolicy(policy), WithHTTPClient(ts.Client()), WithAPIKey("secret")},
+	}
+	for name, opts := range orders {
+		calls.Store(0)
+		c, err := NewClient(ts.URL, opts...)
+		if err != nil {
+			t.Fatalf("%s: failed to create client: %v", name, err)
+		}
+		if _, err := c.UUIDv4(context.Background()); err == nil {
+			t.Fatalf("%s: expected error from exhausted retries", name)
+		}
+		if got := calls.Load(); got != 3 {
+			t.Fatalf("%s: expected 3 calls, got %d", name, got)
+		}
+	}
+}
+
+func newRetryTestClient(t *testing.T, ts *httptest.Server, policy RetryPolicy) *Client {
+	t.Helper()
+	client, err := NewClient(
+		ts.URL,
+		WithHTTPClient(ts.Client()),
+		WithRetryPolicy(policy),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
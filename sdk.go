@@ -1,11 +1,13 @@
 package uuidify
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"strings"
 	"time"
@@ -32,6 +34,26 @@ func NewDefaultClient(opts ...ClientOption) (*Client, error) {
 	return client, nil
 }
 
+// WithResponseFormat asks the API to answer in the given format. With Text
+// the SDK reads the newline-delimited body line by line instead of decoding
+// JSON, which is noticeably smaller on the wire for large batches.
+func WithResponseFormat(format GetParamsFormat) ClientOption {
+	return func(c *Client) error {
+		switch format {
+		case Json, Text:
+		default:
+			return fmt.Errorf("format must be one of json, text")
+		}
+		c.RequestEditors = append(c.RequestEditors, func(ctx context.Context, req *http.Request) error {
+			q := req.URL.Query()
+			q.Set("format", string(format))
+			req.URL.RawQuery = q.Encode()
+			return nil
+		})
+		return nil
+	}
+}
+
 // WithUserAgent ensures every request carries the provided User-Agent header.
 func WithUserAgent(ua string) ClientOption {
 	return func(c *Client) error {
@@ -63,14 +85,11 @@ func (c *Client) UUIDv7(ctx context.Context) (string, error) {
 
 // ULID fetches a ULID value.
 func (c *Client) ULID(ctx context.Context) (string, error) {
-	var resp struct {
-		ULID string `json:"ulid"`
-	}
-	params := &GetParams{Version: ptrVersion(GetParamsVersionUlid)}
-	if err := c.invoke(ctx, params, &resp); err != nil {
+	ids, _, err := c.fetch(ctx, GetParamsVersionUlid, 1)
+	if err != nil {
 		return "", err
 	}
-	return resp.ULID, nil
+	return ids[0], nil
 }
 
 // UUIDBatch fetches multiple UUIDs of the given version.
@@ -79,72 +98,194 @@ func (c *Client) UUIDBatch(ctx context.Context, version string, count int) ([]st
 	if !isSupportedUUIDVersion(ver) {
 		return nil, fmt.Errorf("version must be one of v1, v4, v7")
 	}
-	if count <= 0 || count > 1000 {
+	if count <= 0 || count > maxBatchCount {
 		return nil, fmt.Errorf("count must be between 1 and 1000")
 	}
 
-	params := &GetParams{
-		Version: ptrVersion(ver),
-		Count:   ptrCount(count),
+	ids, _, err := c.fetch(ctx, ver, count)
+	if err != nil {
+		return nil, err
 	}
+	return ids, nil
+}
 
-	if count == 1 {
-		id, err := c.singleUUID(ctx, ver)
-		if err != nil {
-			return nil, err
-		}
-		return []string{id}, nil
+// ULIDBatch fetches multiple ULIDs.
+func (c *Client) ULIDBatch(ctx context.Context, count int) ([]string, error) {
+	if count <= 0 || count > maxBatchCount {
+		return nil, fmt.Errorf("count must be between 1 and 1000")
 	}
 
-	var resp struct {
-		UUIDs []string `json:"uuids"`
-	}
-	if err := c.invoke(ctx, params, &resp); err != nil {
+	ids, _, err := c.fetch(ctx, GetParamsVersionUlid, count)
+	if err != nil {
 		return nil, err
 	}
-	return resp.UUIDs, nil
+	return ids, nil
 }
 
-// ULIDBatch fetches multiple ULIDs.
-func (c *Client) ULIDBatch(ctx context.Context, count int) ([]string, error) {
-	if count <= 0 || count > 1000 {
-		return nil, fmt.Errorf("count must be between 1 and 1000")
+// TypedUUIDv1 fetches a UUID v1 value and parses it into a UUID.
+func (c *Client) TypedUUIDv1(ctx context.Context) (UUID, error) {
+	return parseUUIDResult(c.UUIDv1(ctx))
+}
+
+// TypedUUIDv4 fetches a UUID v4 value and parses it into a UUID.
+func (c *Client) TypedUUIDv4(ctx context.Context) (UUID, error) {
+	return parseUUIDResult(c.UUIDv4(ctx))
+}
+
+// TypedUUIDv7 fetches a UUID v7 value and parses it into a UUID.
+func (c *Client) TypedUUIDv7(ctx context.Context) (UUID, error) {
+	return parseUUIDResult(c.UUIDv7(ctx))
+}
+
+// TypedULID fetches a ULID value and parses it into a ULID.
+func (c *Client) TypedULID(ctx context.Context) (ULID, error) {
+	s, err := c.ULID(ctx)
+	if err != nil {
+		return ULID{}, err
 	}
+	id, err := ParseULID(s)
+	if err != nil {
+		return ULID{}, &DecodeError{Err: err}
+	}
+	return id, nil
+}
 
-	params := &GetParams{
-		Version: ptrVersion(GetParamsVersionUlid),
-		Count:   ptrCount(count),
+// TypedUUIDBatch fetches multiple UUIDs of the given version and parses them.
+func (c *Client) TypedUUIDBatch(ctx context.Context, version string, count int) ([]UUID, error) {
+	raw, err := c.UUIDBatch(ctx, version, count)
+	if err != nil {
+		return nil, err
 	}
+	ids := make([]UUID, len(raw))
+	for i, s := range raw {
+		if ids[i], err = ParseUUID(s); err != nil {
+			return nil, &DecodeError{Err: err}
+		}
+	}
+	return ids, nil
+}
 
-	if count == 1 {
-		id, err := c.ULID(ctx)
-		if err != nil {
-			return nil, err
+// TypedULIDBatch fetches multiple ULIDs and parses them.
+func (c *Client) TypedULIDBatch(ctx context.Context, count int) ([]ULID, error) {
+	raw, err := c.ULIDBatch(ctx, count)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]ULID, len(raw))
+	for i, s := range raw {
+		if ids[i], err = ParseULID(s); err != nil {
+			return nil, &DecodeError{Err: err}
 		}
-		return []string{id}, nil
 	}
+	return ids, nil
+}
 
-	var resp struct {
-		ULIDs []string `json:"ulids"`
+func parseUUIDResult(s string, err error) (UUID, error) {
+	if err != nil {
+		return UUID{}, err
 	}
-	if err := c.invoke(ctx, params, &resp); err != nil {
-		return nil, err
+	u, err := ParseUUID(s)
+	if err != nil {
+		return UUID{}, &DecodeError{Err: err}
 	}
-	return resp.ULIDs, nil
+	return u, nil
 }
 
 func (c *Client) singleUUID(ctx context.Context, version GetParamsVersion) (string, error) {
-	var resp struct {
-		UUID string `json:"uuid"`
+	ids, _, err := c.fetch(ctx, version, 1)
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// fetch performs one API call for count identifiers of the given version and
+// validates what comes back. Single identifiers are requested without a count
+// so the server answers with its single-value shape.
+func (c *Client) fetch(ctx context.Context, version GetParamsVersion, count int) ([]string, *payload, error) {
+	if t := lookupTransport(c); t != nil && len(t.observers) > 0 {
+		return t.observe(ctx, version, count, c.fetchOnce)
 	}
+	return c.fetchOnce(ctx, version, count)
+}
+
+func (c *Client) fetchOnce(ctx context.Context, version GetParamsVersion, count int) ([]string, *payload, error) {
 	params := &GetParams{Version: ptrVersion(version)}
+	if count > 1 {
+		params.Count = ptrCount(count)
+	}
+
+	var resp payload
 	if err := c.invoke(ctx, params, &resp); err != nil {
-		return "", err
+		if t := lookupTransport(c); t != nil && t.fallback != nil && shouldFallback(ctx, err) {
+			return t.fallback.generate(ctx, version, count, err)
+		}
+		return nil, nil, err
+	}
+
+	ids := resp.ids(version, count)
+	if err := validateCount(version, count, len(ids)); err != nil {
+		return nil, &resp, err
+	}
+	if version == GetParamsVersionUlid {
+		if err := validateULIDs(ids); err != nil {
+			return nil, &resp, err
+		}
+	} else if err := validateUUIDs(version, ids); err != nil {
+		return nil, &resp, err
+	}
+	return ids, &resp, nil
+}
+
+// payload is the union of the JSON shapes the API returns on success.
+type payload struct {
+	UUID        string   `json:"uuid"`
+	UUIDs       []string `json:"uuids"`
+	ULID        string   `json:"ulid"`
+	ULIDs       []string `json:"ulids"`
+	GeneratedAt string   `json:"generated_at"`
+
+	// status and format describe the HTTP response the payload came from.
+	status int
+	format GetParamsFormat
+
+	// local is set when the identifiers were generated in-process by the
+	// local fallback rather than by the API, and cause holds the API error
+	// that triggered it.
+	local bool
+	cause error
+}
+
+// ids returns the identifiers of the shape matching version and count.
+func (p *payload) ids(version GetParamsVersion, count int) []string {
+	switch {
+	case version == GetParamsVersionUlid && count == 1:
+		if p.ULID == "" {
+			return nil
+		}
+		return []string{p.ULID}
+	case version == GetParamsVersionUlid:
+		return p.ULIDs
+	case count == 1:
+		if p.UUID == "" {
+			return nil
+		}
+		return []string{p.UUID}
+	default:
+		return p.UUIDs
 	}
-	return resp.UUID, nil
 }
 
-func (c *Client) invoke(ctx context.Context, params *GetParams, v interface{}) error {
+// generatedAt parses the RFC 3339 generated_at field, returning the zero
+// time when the server omitted it (as it does for text responses).
+func (p *payload) generatedAt() (time.Time, error) {
+	if p.GeneratedAt == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, p.GeneratedAt)
+}
+
+func (c *Client) invoke(ctx context.Context, params *GetParams, v *payload) error {
 	if c == nil {
 		return &RequestError{Err: errors.New("client is nil")}
 	}
@@ -163,13 +304,19 @@ func (c *Client) invoke(ctx context.Context, params *GetParams, v interface{}) e
 		if msg == "" {
 			msg = http.StatusText(resp.StatusCode)
 		}
-		return &APIError{StatusCode: resp.StatusCode, Message: msg}
+		return &APIError{StatusCode: resp.StatusCode, Message: lookupTransport(c).redact(msg)}
 	}
 
-	if v == nil {
+	v.status = resp.StatusCode
+	if isTextPlain(resp.Header.Get("Content-Type")) {
+		v.format = Text
+		if err := decodeText(resp.Body, params, v); err != nil {
+			return &DecodeError{Err: err}
+		}
 		return nil
 	}
 
+	v.format = Json
 	decoder := json.NewDecoder(resp.Body)
 	if err := decoder.Decode(v); err != nil {
 		if errors.Is(err, io.EOF) {
@@ -181,6 +328,109 @@ func (c *Client) invoke(ctx context.Context, params *GetParams, v interface{}) e
 	return nil
 }
 
+// validateUUIDs checks that every id is a canonical RFC 9562 UUID whose
+// version nibble matches the requested version.
+func validateUUIDs(version GetParamsVersion, ids []string) error {
+	want := uuidVersionNumber(version)
+	for _, s := range ids {
+		u, err := ParseUUID(s)
+		if err != nil {
+			return &ValidationError{Version: version, Value: s, Err: err}
+		}
+		if u.Variant() != VariantRFC4122 {
+			return &ValidationError{Version: version, Value: s, Err: fmt.Errorf("unexpected variant %s", u.Variant())}
+		}
+		if u.Version() != want {
+			return &ValidationError{Version: version, Value: s, Err: fmt.Errorf("unexpected version %d", u.Version())}
+		}
+	}
+	return nil
+}
+
+// validateULIDs checks that every id is 26 valid Crockford base32 characters.
+func validateULIDs(ids []string) error {
+	for _, s := range ids {
+		if _, err := ParseULID(s); err != nil {
+			return &ValidationError{Version: GetParamsVersionUlid, Value: s, Err: err}
+		}
+	}
+	return nil
+}
+
+func validateCount(version GetParamsVersion, want, got int) error {
+	if want != got {
+		return &ValidationError{Version: version, Err: fmt.Errorf("expected %d identifiers, got %d", want, got)}
+	}
+	return nil
+}
+
+func uuidVersionNumber(version GetParamsVersion) Version {
+	switch version {
+	case GetParamsVersionV1:
+		return 1
+	case GetParamsVersionV4:
+		return 4
+	case GetParamsVersionV7:
+		return 7
+	default:
+		return 0
+	}
+}
+
+func isTextPlain(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "text/plain"
+}
+
+// decodeText scans a newline-delimited body into the payload field matching
+// the requested version and count.
+func decodeText(r io.Reader, params *GetParams, v *payload) error {
+	ids, err := scanLines(r)
+	if err != nil {
+		return err
+	}
+
+	ulid := params != nil && params.Version != nil && *params.Version == GetParamsVersionUlid
+	single := params == nil || params.Count == nil || *params.Count == 1
+	switch {
+	case ulid && single:
+		v.ULID = ids[0]
+		if len(ids) > 1 {
+			return fmt.Errorf("expected 1 identifier, got %d", len(ids))
+		}
+	case ulid:
+		v.ULIDs = ids
+	case single:
+		v.UUID = ids[0]
+		if len(ids) > 1 {
+			return fmt.Errorf("expected 1 identifier, got %d", len(ids))
+		}
+	default:
+		v.UUIDs = ids
+	}
+	return nil
+}
+
+// scanLines returns the non-blank lines of a text/plain body.
+func scanLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return lines, nil
+}
+
 func isSupportedUUIDVersion(version GetParamsVersion) bool {
 	switch version {
 	case GetParamsVersionV1, GetParamsVersionV4, GetParamsVersionV7:
@@ -267,3 +517,28 @@ func (e *RequestError) Unwrap() error {
 	}
 	return e.Err
 }
+
+// ValidationError reports an identifier returned by the API that is malformed
+// or does not match the requested version.
+type ValidationError struct {
+	Version GetParamsVersion
+	Value   string
+	Err     error
+}
+
+func (e *ValidationError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	if e.Value != "" {
+		return fmt.Sprintf("uuidify validation error (%s %q): %v", e.Version, e.Value, e.Err)
+	}
+	return fmt.Sprintf("uuidify validation error (%s): %v", e.Version, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Err
+}
@@ -0,0 +1,191 @@
+package uuidify
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Scan implements sql.Scanner. It accepts 16-byte binary values, as stored
+// in BINARY(16) or BYTEA columns, and textual values in any form accepted
+// by ParseUUIDLenient, as returned for Postgres uuid and CHAR(36) columns.
+func (u *UUID) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return u.scanText(src)
+	case []byte:
+		if len(src) == len(u) {
+			copy(u[:], src)
+			return nil
+		}
+		return u.scanText(string(src))
+	case nil:
+		return fmt.Errorf("cannot scan NULL into UUID; use NullUUID")
+	default:
+		return fmt.Errorf("cannot scan %T into UUID", src)
+	}
+}
+
+func (u *UUID) scanText(s string) error {
+	parsed, err := ParseUUIDLenient(s)
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, storing u in its canonical text form. Use
+// BinaryUUID to write a binary column.
+func (u UUID) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+// Scan implements sql.Scanner. It accepts 16-byte binary values and the
+// 26 character text form in either case.
+func (id *ULID) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return id.scanText(src)
+	case []byte:
+		if len(src) == len(id) {
+			copy(id[:], src)
+			return nil
+		}
+		return id.scanText(string(src))
+	case nil:
+		return fmt.Errorf("cannot scan NULL into ULID; use NullULID")
+	default:
+		return fmt.Errorf("cannot scan %T into ULID", src)
+	}
+}
+
+func (id *ULID) scanText(s string) error {
+	parsed, err := ParseULIDLenient(s)
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, storing id in its 26 character text form.
+// Use BinaryULID to write a binary column.
+func (id ULID) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+// NullUUID is a UUID that may be NULL in the database. It implements
+// sql.Scanner and driver.Valuer like sql.NullString.
+type NullUUID struct {
+	UUID  UUID
+	Valid bool // Valid is true if UUID is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (n *NullUUID) Scan(src any) error {
+	if src == nil {
+		n.UUID, n.Valid = UUID{}, false
+		return nil
+	}
+	if err := n.UUID.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullUUID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.UUID.Value()
+}
+
+// NullULID is a ULID that may be NULL in the database. It implements
+// sql.Scanner and driver.Valuer like sql.NullString.
+type NullULID struct {
+	ULID  ULID
+	Valid bool // Valid is true if ULID is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (n *NullULID) Scan(src any) error {
+	if src == nil {
+		n.ULID, n.Valid = ULID{}, false
+		return nil
+	}
+	if err := n.ULID.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullULID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.ULID.Value()
+}
+
+// BinaryUUID is a UUID stored as 16 raw bytes, for BINARY(16) and BYTEA
+// columns. It scans like UUID.
+type BinaryUUID struct {
+	UUID
+}
+
+// Value implements driver.Valuer, storing u as 16 bytes.
+func (u BinaryUUID) Value() (driver.Value, error) {
+	return u.Bytes(), nil
+}
+
+// BinaryULID is a ULID stored as 16 raw bytes, for BINARY(16) and BYTEA
+// columns. It scans like ULID.
+type BinaryULID struct {
+	ULID
+}
+
+// Value implements driver.Valuer, storing id as 16 bytes.
+func (id BinaryULID) Value() (driver.Value, error) {
+	return id.Bytes(), nil
+}
+
+// NullBinaryUUID is a BinaryUUID that may be NULL in the database.
+type NullBinaryUUID struct {
+	UUID  UUID
+	Valid bool // Valid is true if UUID is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (n *NullBinaryUUID) Scan(src any) error {
+	return (*NullUUID)(n).Scan(src)
+}
+
+// Value implements driver.Valuer.
+func (n NullBinaryUUID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.UUID.Bytes(), nil
+}
+
+// NullBinaryULID is a BinaryULID that may be NULL in the database.
+type NullBinaryULID struct {
+	ULID  ULID
+	Valid bool // Valid is true if ULID is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (n *NullBinaryULID) Scan(src any) error {
+	return (*NullULID)(n).Scan(src)
+}
+
+// Value implements driver.Valuer.
+func (n NullBinaryULID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.ULID.Bytes(), nil
+}
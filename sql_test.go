@@ -0,0 +1,253 @@
+package uuidify
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+const (
+	sqlUUID = "550e8400-e29b-41d4-a716-446655440000"
+	sqlULID = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+)
+
+func TestSQL_ScanUUID(t *testing.T) {
+	t.Parallel()
+
+	want := MustParseUUID(sqlUUID)
+	db := openFakeDB(t, &fakeConnector{rows: [][]driver.Value{
+		{sqlUUID},
+		{[]byte("550E8400-E29B-41D4-A716-446655440000")},
+		{want.Bytes()},
+		{nil},
+	}})
+
+	rows, err := db.Query("SELECT id FROM t")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	defer rows.Close()
+
+	var got []NullUUID
+	for rows.Next() {
+		var n NullUUID
+		if err := rows.Scan(&n); err != nil {
+			t.Fatalf("Scan returned error: %v", err)
+		}
+		got = append(got, n)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("rows returned error: %v", err)
+	}
+	if len(got) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(got))
+	}
+	for i, n := range got[:3] {
+		if !n.Valid || n.UUID != want {
+			t.Fatalf("row %d: expected %s, got %+v", i, want, n)
+		}
+	}
+	if got[3].Valid {
+		t.Fatalf("expected NULL row to be invalid, got %+v", got[3])
+	}
+}
+
+func TestSQL_ScanULID(t *testing.T) {
+	t.Parallel()
+
+	want := MustParseULID(sqlULID)
+	db := openFakeDB(t, &fakeConnector{rows: [][]driver.Value{
+		{sqlULID},
+		{"01arz3ndektsv4rrffq69g5fav"},
+		{want.Bytes()},
+	}})
+
+	rows, err := db.Query("SELECT id FROM t")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		var id ULID
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("Scan returned error: %v", err)
+		}
+		if id != want {
+			t.Fatalf("row %d: expected %s, got %s", n, want, id)
+		}
+		n++
+	}
+	if n != 3 {
+		t.Fatalf("expected 3 rows, got %d", n)
+	}
+}
+
+func TestSQL_ScanErrors(t *testing.T) {
+	t.Parallel()
+
+	var u UUID
+	var id ULID
+	for _, tc := range []struct {
+		name string
+		err  error
+	}{
+		{"uuid null", u.Scan(nil)},
+		{"uuid int", u.Scan(int64(1))},
+		{"uuid short bytes", u.Scan([]byte{1, 2, 3})},
+		{"uuid garbage", u.Scan("not-a-uuid")},
+		{"ulid null", id.Scan(nil)},
+		{"ulid garbage", id.Scan("not-a-ulid")},
+	} {
+		if tc.err == nil {
+			t.Fatalf("%s: expected error", tc.name)
+		}
+	}
+}
+
+func TestSQL_Value(t *testing.T) {
+	t.Parallel()
+
+	conn := &fakeConnector{}
+	db := openFakeDB(t, conn)
+
+	u := MustParseUUID(sqlUUID)
+	id := MustParseULID(sqlULID)
+	_, err := db.Exec("INSERT INTO t VALUES (?, ?, ?, ?, ?, ?)",
+		u, id, NullUUID{UUID: u, Valid: true}, NullUUID{}, NullULID{ULID: id, Valid: true}, u.Bytes())
+	if err != nil {
+		t.Fatalf("Exec returned error: %v", err)
+	}
+
+	want := []driver.Value{sqlUUID, sqlULID, sqlUUID, nil, sqlULID, u.Bytes()}
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	if len(conn.args) != len(want) {
+		t.Fatalf("expected %d args, got %d", len(want), len(conn.args))
+	}
+	for i, w := range want {
+		if b, ok := w.([]byte); ok {
+			if got, ok := conn.args[i].([]byte); !ok || string(got) != string(b) {
+				t.Fatalf("arg %d: expected %x, got %v", i, b, conn.args[i])
+			}
+			continue
+		}
+		if conn.args[i] != w {
+			t.Fatalf("arg %d: expected %v, got %v", i, w, conn.args[i])
+		}
+	}
+}
+
+func TestSQL_BinaryValue(t *testing.T) {
+	t.Parallel()
+
+	conn := &fakeConnector{}
+	db := openFakeDB(t, conn)
+
+	u := MustParseUUID(sqlUUID)
+	id := MustParseULID(sqlULID)
+	_, err := db.Exec("INSERT INTO t VALUES (?, ?, ?, ?, ?, ?)",
+		BinaryUUID{u}, BinaryULID{id},
+		NullBinaryUUID{UUID: u, Valid: true}, NullBinaryUUID{},
+		NullBinaryULID{ULID: id, Valid: true}, NullBinaryULID{})
+	if err != nil {
+		t.Fatalf("Exec returned error: %v", err)
+	}
+
+	want := []driver.Value{u.Bytes(), id.Bytes(), u.Bytes(), nil, id.Bytes(), nil}
+	conn.mu.Lock()
+	args := conn.args
+	conn.mu.Unlock()
+	if len(args) != len(want) {
+		t.Fatalf("expected %d args, got %d", len(want), len(args))
+	}
+	for i, w := range want {
+		if w == nil {
+			if args[i] != nil {
+				t.Fatalf("arg %d: expected NULL, got %v", i, args[i])
+			}
+			continue
+		}
+		if got, ok := args[i].([]byte); !ok || string(got) != string(w.([]byte)) {
+			t.Fatalf("arg %d: expected %x, got %v", i, w, args[i])
+		}
+	}
+
+	conn.rows = [][]driver.Value{{args[0]}}
+	var gotUUID BinaryUUID
+	if err := db.QueryRow("SELECT id FROM t").Scan(&gotUUID); err != nil || gotUUID.UUID != u {
+		t.Fatalf("expected %s, got %s (%v)", u, gotUUID, err)
+	}
+	conn.rows = [][]driver.Value{{args[5]}}
+	nullULID := NullBinaryULID{ULID: id, Valid: true}
+	if err := db.QueryRow("SELECT id FROM t").Scan(&nullULID); err != nil || nullULID.Valid {
+		t.Fatalf("expected NULL, got %+v (%v)", nullULID, err)
+	}
+}
+
+func openFakeDB(t *testing.T, c *fakeConnector) *sql.DB {
+	t.Helper()
+	db := sql.OpenDB(c)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// fakeConnector is a minimal database/sql driver that serves fixed
+// single-column rows and records the arguments of the last Exec.
+type fakeConnector struct {
+	rows [][]driver.Value
+
+	mu   sync.Mutex
+	args []driver.Value
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{c}, nil }
+func (c *fakeConnector) Driver() driver.Driver                        { return fakeDriver{c} }
+
+type fakeDriver struct{ c *fakeConnector }
+
+func (d fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{d.c}, nil }
+
+type fakeConn struct{ c *fakeConnector }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt{c.c}, nil }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("transactions not supported") }
+
+type fakeStmt struct{ c *fakeConnector }
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	s.c.args = args
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{rows: s.c.rows}, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+	pos  int
+}
+
+func (r *fakeRows) Columns() []string { return []string{"id"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.pos])
+	r.pos++
+	return nil
+}
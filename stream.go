@@ -0,0 +1,97 @@
+package uuidify
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// ID is an identifier yielded by Stream: a UUID for versions v1, v4 and v7,
+// or a ULID. Use a type switch to get at the concrete value.
+type ID interface {
+	String() string
+	Bytes() []byte
+	IsZero() bool
+}
+
+var (
+	_ ID = UUID{}
+	_ ID = ULID{}
+)
+
+// Stream returns an iterator over an unbounded sequence of identifiers of
+// the given version ("v1", "v4", "v7" or "ulid"), fetched behind the scenes
+// in batches of 1000 so that memory stays bounded:
+//
+//	for id, err := range client.Stream(ctx, "v7") {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// The sequence ends after yielding the first error, including the context
+// error once ctx is done, or when the loop breaks.
+func (c *Client) Stream(ctx context.Context, version string) iter.Seq2[ID, error] {
+	return c.StreamN(ctx, version, -1)
+}
+
+// StreamN is like Stream but stops after n identifiers, sizing the last
+// batch so that no more than n are fetched. A negative n means no limit.
+func (c *Client) StreamN(ctx context.Context, version string, n int) iter.Seq2[ID, error] {
+	return func(yield func(ID, error) bool) {
+		ver := GetParamsVersion(version)
+		if !isSupportedUUIDVersion(ver) && ver != GetParamsVersionUlid {
+			yield(nil, fmt.Errorf("version must be one of v1, v4, v7, ulid"))
+			return
+		}
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		for remaining := n; remaining != 0; {
+			count := maxBatchCount
+			if remaining > 0 {
+				count = min(count, remaining)
+				remaining -= count
+			}
+
+			batch, err := c.streamBatch(ctx, ver, count)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, id := range batch {
+				if !yield(id, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *Client) streamBatch(ctx context.Context, version GetParamsVersion, count int) ([]ID, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	batch := make([]ID, 0, count)
+	if version == GetParamsVersionUlid {
+		ids, err := c.TypedULIDBatch(ctx, count)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			batch = append(batch, id)
+		}
+		return batch, nil
+	}
+
+	ids, err := c.TypedUUIDBatch(ctx, string(version), count)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		batch = append(batch, id)
+	}
+	return batch, nil
+}
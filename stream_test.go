@@ -0,0 +1,104 @@
+package uuidify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestStreamN_FetchesInBatches(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := newBatchServer(t, &calls)
+	defer ts.Close()
+	c := newTestClient(t, ts)
+
+	seen := make(map[UUID]bool)
+	for id, err := range c.StreamN(context.Background(), "v7", 2500) {
+		if err != nil {
+			t.Fatalf("StreamN returned error: %v", err)
+		}
+		u, ok := id.(UUID)
+		if !ok {
+			t.Fatalf("expected UUID, got %T", id)
+		}
+		if u.Version() != 7 {
+			t.Fatalf("expected version 7, got %d", u.Version())
+		}
+		seen[u] = true
+	}
+	if len(seen) != 2500 {
+		t.Fatalf("expected 2500 unique ids, got %d", len(seen))
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("expected 3 calls, got %d", got)
+	}
+}
+
+func TestStream_StopsOnBreak(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := newBatchServer(t, &calls)
+	defer ts.Close()
+	c := newTestClient(t, ts)
+
+	n := 0
+	for id, err := range c.Stream(context.Background(), "ulid") {
+		if err != nil {
+			t.Fatalf("Stream returned error: %v", err)
+		}
+		if _, ok := id.(ULID); !ok {
+			t.Fatalf("expected ULID, got %T", id)
+		}
+		n++
+		if n == 1500 {
+			break
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestStream_YieldsError(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error":"unavailable"}`))
+	}))
+	defer ts.Close()
+	c := newTestClient(t, ts)
+
+	var errs []error
+	for id, err := range c.Stream(context.Background(), "v4") {
+		if id != nil {
+			t.Fatalf("expected no id, got %v", id)
+		}
+		errs = append(errs, err)
+	}
+	var apiErr *APIError
+	if len(errs) != 1 || !errors.As(errs[0], &apiErr) {
+		t.Fatalf("expected a single APIError, got %v", errs)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range c.Stream(ctx, "v4") {
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context canceled, got %v", err)
+		}
+	}
+
+	for _, err := range c.Stream(context.Background(), "v9") {
+		if err == nil {
+			t.Fatal("expected error for invalid version")
+		}
+	}
+}
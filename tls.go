@@ -0,0 +1,193 @@
+package uuidify
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// The options in this file configure the *http.Transport underneath the
+// client. NewClient copies the final *http.Client, whether it comes from
+// WithHTTPClient or not, and clones its transport, or http.DefaultTransport
+// when it has none, so that shared clients such as http.DefaultClient are
+// never modified. NewClient fails if the Doer is not an *http.Client backed
+// by an *http.Transport.
+
+// WithRootCAs verifies the server certificate against pool instead of the
+// system roots.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(c *Client) error {
+		if pool == nil {
+			return errors.New("root CA pool is nil")
+		}
+		configureHTTPTransport(c, func(tr *http.Transport) {
+			tr.TLSClientConfig.RootCAs = pool
+		})
+		return nil
+	}
+}
+
+// WithRootCAFile trusts the PEM encoded certificates in path in addition to
+// the system roots.
+func WithRootCAFile(path string) ClientOption {
+	return func(c *Client) error {
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no PEM certificates found in %s", path)
+		}
+		return WithRootCAs(pool)(c)
+	}
+}
+
+// WithClientCertFiles presents the certificate and key in certFile and
+// keyFile for mutual TLS. The files are checked on every new connection and
+// reloaded when they change, so rotated certificates are picked up without
+// restarting; established connections keep the certificate they were opened
+// with.
+func WithClientCertFiles(certFile, keyFile string) ClientOption {
+	return func(c *Client) error {
+		r := &certReloader{certFile: certFile, keyFile: keyFile}
+		if _, err := r.load(); err != nil {
+			return err
+		}
+		configureHTTPTransport(c, func(tr *http.Transport) {
+			tr.TLSClientConfig.GetClientCertificate = r.getClientCertificate
+		})
+		return nil
+	}
+}
+
+// WithMinTLSVersion refuses connections below version, such as
+// tls.VersionTLS13.
+func WithMinTLSVersion(version uint16) ClientOption {
+	return func(c *Client) error {
+		if version < tls.VersionTLS10 || version > tls.VersionTLS13 {
+			return fmt.Errorf("unsupported TLS version %#04x", version)
+		}
+		configureHTTPTransport(c, func(tr *http.Transport) {
+			tr.TLSClientConfig.MinVersion = version
+		})
+		return nil
+	}
+}
+
+// WithProxy sends requests through the proxy at proxyURL, which may use the
+// http, https or socks5 scheme, instead of the one given by the standard
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY variables.
+func WithProxy(proxyURL string) ClientOption {
+	return func(c *Client) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return fmt.Errorf("proxy scheme must be one of http, https, socks5")
+		}
+		if u.Host == "" {
+			return fmt.Errorf("proxy URL %s has no host", u.Redacted())
+		}
+		configureHTTPTransport(c, func(tr *http.Transport) {
+			tr.Proxy = http.ProxyURL(u)
+		})
+		return nil
+	}
+}
+
+// configureHTTPTransport records configure for the *http.Transport of c.
+func configureHTTPTransport(c *Client, configure func(*http.Transport)) {
+	t := transportFor(c)
+	t.httpConfig = append(t.httpConfig, configure)
+}
+
+// configuredHTTPClient returns a copy of doer whose transport is a clone of
+// doer's with config applied.
+func configuredHTTPClient(doer HttpRequestDoer, config []func(*http.Transport)) (*http.Client, error) {
+	hc, ok := doer.(*http.Client)
+	if !ok {
+		return nil, fmt.Errorf("TLS and proxy options require an *http.Client, got %T", doer)
+	}
+	var tr *http.Transport
+	switch rt := hc.Transport.(type) {
+	case nil:
+		tr = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		tr = rt.Clone()
+	default:
+		return nil, fmt.Errorf("TLS and proxy options require an *http.Transport, got %T", hc.Transport)
+	}
+	if tr.TLSClientConfig == nil {
+		tr.TLSClientConfig = &tls.Config{}
+	}
+	for _, configure := range config {
+		configure(tr)
+	}
+
+	copied := *hc
+	copied.Transport = tr
+	return &copied, nil
+}
+
+// certReloader serves a client certificate from disk, reloading it when
+// either file's modification time changes.
+type certReloader struct {
+	certFile, keyFile string
+
+	mu              sync.Mutex
+	cert            *tls.Certificate
+	certMod, keyMod time.Time
+}
+
+func (r *certReloader) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	cert, err := r.load()
+	if err != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		// Keep the last good certificate while files are mid-rotation.
+		if r.cert != nil {
+			return r.cert, nil
+		}
+		return nil, err
+	}
+	return cert, nil
+}
+
+// load returns the current certificate, rereading the files if they changed.
+func (r *certReloader) load() (*tls.Certificate, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return nil, err
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cert != nil && certInfo.ModTime().Equal(r.certMod) && keyInfo.ModTime().Equal(r.keyMod) {
+		return r.cert, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load client certificate: %w", err)
+	}
+	r.cert = &cert
+	r.certMod, r.keyMod = certInfo.ModTime(), keyInfo.ModTime()
+	return r.cert, nil
+}
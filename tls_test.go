@@ -0,0 +1,234 @@
+package uuidify
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithClientCertFiles_ReloadsRotatedCertificate(t *testing.T) {
+	t.Parallel()
+
+	ca, caKey := newTestCA(t)
+
+	var mu sync.Mutex
+	var seen []string
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.TLS.PeerCertificates[0].Subject.CommonName)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"uuid":"550e8400-e29b-41d4-a716-446655440000"}`))
+	}))
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	ts.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool}
+	ts.Config.SetKeepAlivesEnabled(false)
+	ts.StartTLS()
+	defer ts.Close()
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	writeClientCert(t, ca, caKey, "client-1", certFile, keyFile, time.Now())
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ts.Certificate())
+	c, err := NewDefaultClient(
+		WithBaseURL(ts.URL),
+		WithRootCAs(roots),
+		WithClientCertFiles(certFile, keyFile),
+		WithMinTLSVersion(tls.VersionTLS12),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := c.UUIDv4(context.Background()); err != nil {
+		t.Fatalf("UUIDv4 returned error: %v", err)
+	}
+	writeClientCert(t, ca, caKey, "client-2", certFile, keyFile, time.Now().Add(time.Minute))
+	if _, err := c.UUIDv4(context.Background()); err != nil {
+		t.Fatalf("UUIDv4 returned error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) != 2 || seen[0] != "client-1" || seen[1] != "client-2" {
+		t.Fatalf("expected client-1 then client-2, got %v", seen)
+	}
+}
+
+func TestWithRootCAs_RejectsUnknownServer(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	c, err := NewDefaultClient(WithBaseURL(ts.URL), WithRootCAs(x509.NewCertPool()))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := c.UUIDv4(context.Background()); err == nil {
+		t.Fatal("expected certificate verification error")
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Host != "uuidify.internal" {
+			t.Errorf("expected absolute request for uuidify.internal, got %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"uuid":"550e8400-e29b-41d4-a716-446655440000"}`))
+	}))
+	defer proxy.Close()
+
+	c, err := NewDefaultClient(WithBaseURL("http://uuidify.internal"), WithProxy(proxy.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := c.UUIDv4(context.Background()); err != nil {
+		t.Fatalf("UUIDv4 returned error: %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 proxied call, got %d", got)
+	}
+
+	for _, u := range []string{"ftp://proxy.example.com", "socks5://", "::"} {
+		if _, err := NewDefaultClient(WithProxy(u)); err == nil {
+			t.Fatalf("expected error for proxy %q", u)
+		}
+	}
+	if _, err := NewDefaultClient(WithProxy("socks5://127.0.0.1:1080")); err != nil {
+		t.Fatalf("expected SOCKS5 proxy to be accepted, got %v", err)
+	}
+}
+
+func TestTLSOptions_DoNotModifySharedClient(t *testing.T) {
+	t.Parallel()
+
+	shared := &http.Client{}
+	if _, err := NewClient(DefaultBaseURL, WithHTTPClient(shared), WithMinTLSVersion(tls.VersionTLS13), WithProxy("http://proxy.example.com:3128")); err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if shared.Transport != nil {
+		t.Fatalf("expected shared client to be left alone, got %T", shared.Transport)
+	}
+
+	if _, err := NewClient(DefaultBaseURL, WithHTTPClient(roundTripClient{}), WithMinTLSVersion(tls.VersionTLS13)); err == nil {
+		t.Fatal("expected error for a non-http.Client doer")
+	}
+}
+
+func TestTLSOptions_BeforeWithHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"uuid":"550e8400-e29b-41d4-a716-446655440000"}`))
+	}))
+	defer proxy.Close()
+
+	shared := &http.Client{}
+	c, err := NewClient("http://uuidify.internal", WithProxy(proxy.URL), WithHTTPClient(shared))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := c.UUIDv4(context.Background()); err != nil {
+		t.Fatalf("UUIDv4 returned error: %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 proxied call, got %d", got)
+	}
+	if shared.Transport != nil {
+		t.Fatalf("expected shared client to be left alone, got %T", shared.Transport)
+	}
+
+	if _, err := NewClient(DefaultBaseURL, WithMinTLSVersion(tls.VersionTLS13), WithHTTPClient(roundTripClient{})); err == nil {
+		t.Fatal("expected error for a non-http.Client doer")
+	}
+}
+
+type roundTripClient struct{}
+
+func (roundTripClient) Do(*http.Request) (*http.Response, error) { return nil, nil }
+
+func newTestCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "uuidify test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate returned error: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate returned error: %v", err)
+	}
+	return cert, key
+}
+
+// writeClientCert issues a client certificate for cn and writes it to disk
+// with the given modification time.
+func writeClientCert(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, cn, certFile, keyFile string, mod time.Time) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("CreateCertificate returned error: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey returned error: %v", err)
+	}
+
+	writeFile(t, certFile, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+	writeFile(t, keyFile, string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})))
+	for _, f := range []string{certFile, keyFile} {
+		if err := os.Chtimes(f, mod, mod); err != nil {
+			t.Fatalf("Chtimes returned error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package uuidify
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// transport is the HttpRequestDoer installed by the SDK's resilience options.
+// It wraps the configured Doer so that features compose in a fixed order no
+// matter in which order the corresponding ClientOptions are applied.
+//
+// The options only record their settings in the transport; NewClient
+// installs it in front of the final Doer once every option, WithHTTPClient
+// included, has run. The transport also carries settings consulted outside
+// of Do, such as the local fallback.
+type transport struct {
+	next HttpRequestDoer
+	// httpConfig holds the settings of the TLS and proxy options, applied
+	// to a private copy of next's *http.Transport by install.
+	httpConfig []func(*http.Transport)
+
+	retry     *RetryPolicy
+	limiter   *rateLimiter
+	breaker   *circuitBreaker
+	endpoints *endpointPool
+	fallback  *localFallback
+	observers []Observer
+
+	tokens  TokenSource
+	secrets secrets
+
+	logger     *slog.Logger
+	bodyPolicy BodyLogPolicy
+}
+
+// transportFor returns the SDK transport of c, creating it if needed.
+func transportFor(c *Client) *transport {
+	if c.sdk == nil {
+		c.sdk = &transport{}
+	}
+	return c.sdk
+}
+
+// lookupTransport returns the SDK transport of c, or nil if no SDK option
+// was used.
+func lookupTransport(c *Client) *transport {
+	return c.sdk
+}
+
+// install wraps c.Client in t, applying the TLS and proxy settings to it
+// first. NewClient calls it after all options have been applied.
+func (t *transport) install(c *Client) error {
+	next := c.Client
+	if len(t.httpConfig) > 0 {
+		var err error
+		if next, err = configuredHTTPClient(next, t.httpConfig); err != nil {
+			return err
+		}
+	}
+	t.next = next
+	c.Client = t
+	return nil
+}
+
+func (t *transport) Do(req *http.Request) (*http.Response, error) {
+	send := t.next.Do
+	if t.logger != nil {
+		next := send
+		send = func(req *http.Request) (*http.Response, error) {
+			return t.logAttempt(req, next)
+		}
+	}
+	if t.limiter != nil {
+		next := send
+		send = func(req *http.Request) (*http.Response, error) {
+			return t.limiter.do(req, next)
+		}
+	}
+	if t.endpoints != nil {
+		next := send
+		send = func(req *http.Request) (*http.Response, error) {
+			return t.endpoints.do(req, next)
+		}
+	}
+	if t.retry != nil {
+		next := send
+		send = func(req *http.Request) (*http.Response, error) {
+			return t.retry.do(req, next, t.logRetry)
+		}
+	}
+	if t.tokens != nil {
+		next := send
+		send = func(req *http.Request) (*http.Response, error) {
+			return t.refreshOnUnauthorized(req, next)
+		}
+	}
+	if t.breaker != nil {
+		return t.breaker.do(req, send)
+	}
+	return send(req)
+}
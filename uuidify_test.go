@@ -17,7 +17,7 @@ func TestUUIDv4_Single(t *testing.T) {
 			t.Fatalf("expected version v4, got %s", got)
 		}
 		w.Header().Set("Content-Type", "application/json")
-		io.WriteString(w, `{"uuid":"1234"}`)
+		io.WriteString(w, `{"uuid":"550e8400-e29b-41d4-a716-446655440000"}`)
 	}))
 	defer ts.Close()
 
@@ -27,8 +27,8 @@ c := newTestClient(t, ts)
 	if err != nil {
 		t.Fatalf("UUIDv4 returned error: %v", err)
 	}
-	if uuid != "1234" {
-		t.Fatalf("expected uuid 1234, got %s", uuid)
+	if uuid != "550e8400-e29b-41d4-a716-446655440000" {
+		t.Fatalf("expected uuid 550e8400-e29b-41d4-a716-446655440000, got %s", uuid)
 	}
 }
 
@@ -44,7 +44,13 @@ func TestUUIDv7_Batch(t *testing.T) {
 			t.Fatalf("expected count 5, got %s", got)
 		}
 		w.Header().Set("Content-Type", "application/json")
-		io.WriteString(w, `{"uuids":["a","b","c","d","e"]}`)
+		io.WriteString(w, `{"uuids":[
+			"01890a5d-ac96-774b-bcce-b302099a8057",
+			"01890a5d-ac96-774b-bcce-b302099a8058",
+			"01890a5d-ac96-774b-bcce-b302099a8059",
+			"01890a5d-ac96-774b-bcce-b302099a805a",
+			"01890a5d-ac96-774b-bcce-b302099a805b"
+		]}`)
 	}))
 	defer ts.Close()
 
@@ -67,7 +73,7 @@ func TestULID_Single(t *testing.T) {
 			t.Fatalf("expected version ulid, got %s", got)
 		}
 		w.Header().Set("Content-Type", "application/json")
-		io.WriteString(w, `{"ulid":"01J123"}`)
+		io.WriteString(w, `{"ulid":"01HX7D9PMV4NQVP3J8B1R6R6FZ"}`)
 	}))
 	defer ts.Close()
 
@@ -77,8 +83,8 @@ c := newTestClient(t, ts)
 	if err != nil {
 		t.Fatalf("ULID returned error: %v", err)
 	}
-	if id != "01J123" {
-		t.Fatalf("expected ulid 01J123, got %s", id)
+	if id != "01HX7D9PMV4NQVP3J8B1R6R6FZ" {
+		t.Fatalf("expected ulid 01HX7D9PMV4NQVP3J8B1R6R6FZ, got %s", id)
 	}
 }
 
@@ -94,7 +100,7 @@ func TestULID_Batch(t *testing.T) {
 			t.Fatalf("expected count 3, got %s", got)
 		}
 		w.Header().Set("Content-Type", "application/json")
-		io.WriteString(w, `{"ulids":["a","b","c"]}`)
+		io.WriteString(w, `{"ulids":["01HX7D9PMV4NQVP3J8B1R6R6FZ","01HX7D9PMV4NQVP3J8B1R6R6GA","01HX7D9PMV4NQVP3J8B1R6R6GB"]}`)
 	}))
 	defer ts.Close()
 
@@ -109,6 +115,55 @@ c := newTestClient(t, ts)
 	}
 }
 
+func TestUUIDBatch_TextFormat(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("format"); got != "text" {
+			t.Fatalf("expected format text, got %s", got)
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.WriteString(w, "550e8400-e29b-41d4-a716-446655440000\n6ba7b810-9dad-41d1-80b4-00c04fd430c8\n")
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, WithHTTPClient(ts.Client()), WithResponseFormat(Text))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	uuids, err := c.UUIDBatch(context.Background(), "v4", 2)
+	if err != nil {
+		t.Fatalf("UUIDBatch returned error: %v", err)
+	}
+	if len(uuids) != 2 || uuids[1] != "6ba7b810-9dad-41d1-80b4-00c04fd430c8" {
+		t.Fatalf("unexpected uuids %v", uuids)
+	}
+}
+
+func TestULID_TextFormat(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, "01HX7D9PMV4NQVP3J8B1R6R6FZ\n")
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, WithHTTPClient(ts.Client()), WithResponseFormat(Text))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	id, err := c.ULID(context.Background())
+	if err != nil {
+		t.Fatalf("ULID returned error: %v", err)
+	}
+	if id != "01HX7D9PMV4NQVP3J8B1R6R6FZ" {
+		t.Fatalf("unexpected ulid %s", id)
+	}
+}
+
 func TestError_Transport(t *testing.T) {
 	t.Parallel()
 
@@ -176,6 +231,60 @@ c := newTestClient(t, ts)
 	}
 }
 
+func TestError_Validation(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		body string
+		call func(*Client) error
+	}{
+		{
+			name: "malformed uuid",
+			body: `{"uuid":"1234"}`,
+			call: func(c *Client) error { _, err := c.UUIDv4(context.Background()); return err },
+		},
+		{
+			name: "wrong version",
+			body: `{"uuid":"550e8400-e29b-41d4-a716-446655440000"}`,
+			call: func(c *Client) error { _, err := c.UUIDv7(context.Background()); return err },
+		},
+		{
+			name: "wrong variant",
+			body: `{"uuid":"550e8400-e29b-41d4-c716-446655440000"}`,
+			call: func(c *Client) error { _, err := c.UUIDv4(context.Background()); return err },
+		},
+		{
+			name: "short batch",
+			body: `{"uuids":["550e8400-e29b-41d4-a716-446655440000"]}`,
+			call: func(c *Client) error { _, err := c.UUIDBatch(context.Background(), "v4", 2); return err },
+		},
+		{
+			name: "invalid ulid",
+			body: `{"ulid":"01HX7D9PMV4NQVP3J8B1R6R6FU"}`,
+			call: func(c *Client) error { _, err := c.ULID(context.Background()); return err },
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tc.body)
+			}))
+			defer ts.Close()
+
+			err := tc.call(newTestClient(t, ts))
+			var valErr *ValidationError
+			if !errors.As(err, &valErr) {
+				t.Fatalf("expected ValidationError, got %T", err)
+			}
+		})
+	}
+}
+
 func newTestClient(t *testing.T, ts *httptest.Server) *Client {
 	t.Helper()
 	client, err := NewClient(
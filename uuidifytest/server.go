@@ -0,0 +1,277 @@
+// Package uuidifytest provides an in-process fake of the UUIDify API for
+// tests.
+//
+// The fake follows openapi/openapi.yaml: it generates real v1, v4, v7 and ULID
+// values, honors count and format, and answers invalid parameters with the
+// spec's 400 {"error": "..."} body. Hooks allow injecting latency, failures
+// and fixed identifier sequences.
+package uuidifytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	uuidify "github.com/ilkereroglu/uuidify-go"
+	"github.com/ilkereroglu/uuidify-go/internal/idgen"
+)
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithSeed makes generated identifiers reproducible by drawing randomness
+// from a ChaCha8 stream seeded with seed.
+func WithSeed(seed uint64) Option {
+	return func(s *Server) {
+		var key [32]byte
+		for i := 0; i < 8; i++ {
+			key[i] = byte(seed >> (8 * i))
+		}
+		s.rand = rand.NewChaCha8(key)
+	}
+}
+
+// WithClock sets the clock used for embedded timestamps and generated_at.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithLatency delays every response by d.
+func WithLatency(d time.Duration) Option {
+	return func(s *Server) {
+		s.latency = d
+	}
+}
+
+// Server is a fake UUIDify API backed by an httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	rand io.Reader
+	now  func() time.Time
+	gen  *idgen.Generator
+
+	mu       sync.Mutex
+	latency  time.Duration
+	failures []int
+	fixed    []string
+	requests []*http.Request
+}
+
+// NewServer starts a fake UUIDify API. Callers should Close it when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{now: time.Now}
+	for _, o := range opts {
+		o(s)
+	}
+	s.gen = idgen.New(s.rand, s.now)
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// NewClient returns a uuidify.Client pointed at the fake server. opts are
+// applied after the server's base URL and HTTP client.
+func (s *Server) NewClient(opts ...uuidify.ClientOption) (*uuidify.Client, error) {
+	base := []uuidify.ClientOption{uuidify.WithHTTPClient(s.Client())}
+	return uuidify.NewClient(s.URL, append(base, opts...)...)
+}
+
+// SetLatency changes the delay applied to every subsequent response.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// FailNext makes the next n requests fail with the given HTTP status.
+func (s *Server) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, status)
+	}
+}
+
+// Enqueue queues fixed identifiers that are returned, in order, before any
+// generated ones. Queued values are served verbatim regardless of the
+// requested version.
+func (s *Server) Enqueue(ids ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fixed = append(s.fixed, ids...)
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*http.Request(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Clone(r.Context()))
+	latency := s.latency
+	status := 0
+	if len(s.failures) > 0 {
+		status, s.failures = s.failures[0], s.failures[1:]
+	}
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if status != 0 {
+		writeError(w, status, http.StatusText(status))
+		return
+	}
+	if r.Method != http.MethodGet || r.URL.Path != "/" {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	req, msg := parseQuery(r)
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	ids, err := s.generate(req.version, req.count)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if req.format == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.WriteString(w, strings.Join(ids, "\n")+"\n")
+		return
+	}
+
+	key := "uuid"
+	if req.version == "ulid" {
+		key = "ulid"
+	}
+	body := map[string]any{"generated_at": s.now().UTC().Format(time.RFC3339)}
+	if req.count == 1 {
+		body[key] = ids[0]
+	} else {
+		body[key+"s"] = ids
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+type query struct {
+	version string
+	count   int
+	format  string
+}
+
+// parseQuery validates the query parameters against the spec and returns
+// the 400 error message for the first invalid one.
+func parseQuery(r *http.Request) (query, string) {
+	q := r.URL.Query()
+	req := query{version: "v4", count: 1, format: "json"}
+
+	algorithm := "uuid"
+	if v := q.Get("algorithm"); v != "" {
+		algorithm = v
+	}
+	switch algorithm {
+	case "uuid", "ulid":
+	default:
+		return req, "Invalid algorithm parameter"
+	}
+
+	if v := q.Get("version"); v != "" {
+		req.version = v
+	}
+	switch req.version {
+	case "v1", "v4", "v7", "ulid":
+	default:
+		return req, "Invalid version parameter"
+	}
+	if algorithm == "ulid" {
+		req.version = "ulid"
+	}
+
+	if v := q.Get("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 1000 {
+			return req, "Invalid count parameter"
+		}
+		req.count = n
+	}
+
+	if v := q.Get("format"); v != "" {
+		req.format = v
+	}
+	switch req.format {
+	case "json", "text":
+	default:
+		return req, "Invalid format parameter"
+	}
+
+	return req, ""
+}
+
+func (s *Server) generate(version string, count int) ([]string, error) {
+	ids := make([]string, 0, count)
+
+	s.mu.Lock()
+	n := min(count, len(s.fixed))
+	ids = append(ids, s.fixed[:n]...)
+	s.fixed = s.fixed[n:]
+	s.mu.Unlock()
+
+	for len(ids) < count {
+		id, err := s.next(version)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (s *Server) next(version string) (string, error) {
+	switch version {
+	case "v1":
+		u, err := s.gen.V1()
+		return uuidify.UUID(u).String(), err
+	case "v4":
+		u, err := s.gen.V4()
+		return uuidify.UUID(u).String(), err
+	case "v7":
+		u, err := s.gen.V7()
+		return uuidify.UUID(u).String(), err
+	case "ulid":
+		id, err := s.gen.ULID()
+		return uuidify.ULID(id).String(), err
+	default:
+		return "", fmt.Errorf("unsupported version %q", version)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
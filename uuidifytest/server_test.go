@@ -0,0 +1,157 @@
+package uuidifytest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	uuidify "github.com/ilkereroglu/uuidify-go"
+	"github.com/ilkereroglu/uuidify-go/uuidifytest"
+)
+
+func TestServer_GeneratesValidIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	s := uuidifytest.NewServer()
+	defer s.Close()
+
+	c := newClient(t, s)
+	ctx := context.Background()
+
+	for _, version := range []string{"v1", "v4", "v7"} {
+		ids, err := c.UUIDBatch(ctx, version, 10)
+		if err != nil {
+			t.Fatalf("UUIDBatch(%s) returned error: %v", version, err)
+		}
+		if len(ids) != 10 {
+			t.Fatalf("expected 10 ids, got %d", len(ids))
+		}
+	}
+	if _, err := c.ULID(ctx); err != nil {
+		t.Fatalf("ULID returned error: %v", err)
+	}
+
+	res, err := c.GenerateULIDs(ctx, 3)
+	if err != nil {
+		t.Fatalf("GenerateULIDs returned error: %v", err)
+	}
+	if res.GeneratedAt.IsZero() {
+		t.Fatal("expected generated_at to be set")
+	}
+}
+
+func TestServer_TextFormat(t *testing.T) {
+	t.Parallel()
+
+	s := uuidifytest.NewServer()
+	defer s.Close()
+
+	c, err := s.NewClient(uuidify.WithResponseFormat(uuidify.Text))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ids, err := c.ULIDBatch(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("ULIDBatch returned error: %v", err)
+	}
+	if len(ids) != 5 {
+		t.Fatalf("expected 5 ids, got %d", len(ids))
+	}
+}
+
+func TestServer_InvalidParams(t *testing.T) {
+	t.Parallel()
+
+	s := uuidifytest.NewServer()
+	defer s.Close()
+
+	resp, err := http.Get(s.URL + "/?version=v9")
+	if err != nil {
+		t.Fatalf("GET returned error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	parsed, err := uuidify.ParseGetResponse(resp)
+	if err != nil {
+		t.Fatalf("ParseGetResponse returned error: %v", err)
+	}
+	if parsed.StatusCode() != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", parsed.StatusCode())
+	}
+	if parsed.JSON400 == nil || parsed.JSON400.Error == nil || *parsed.JSON400.Error != "Invalid version parameter" {
+		t.Fatalf("unexpected 400 body %s", parsed.Body)
+	}
+}
+
+func TestServer_Hooks(t *testing.T) {
+	t.Parallel()
+
+	s := uuidifytest.NewServer()
+	defer s.Close()
+
+	c := newClient(t, s)
+	ctx := context.Background()
+
+	s.Enqueue("550e8400-e29b-41d4-a716-446655440000")
+	id, err := c.UUIDv4(ctx)
+	if err != nil {
+		t.Fatalf("UUIDv4 returned error: %v", err)
+	}
+	if id != "550e8400-e29b-41d4-a716-446655440000" {
+		t.Fatalf("expected enqueued id, got %s", id)
+	}
+
+	s.FailNext(1, http.StatusServiceUnavailable)
+	_, err = c.UUIDv4(ctx)
+	var apiErr *uuidify.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 APIError, got %v", err)
+	}
+
+	s.SetLatency(time.Second)
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.UUIDv4(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	if got := len(s.Requests()); got != 3 {
+		t.Fatalf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestServer_Seeded(t *testing.T) {
+	t.Parallel()
+
+	now := func() time.Time { return time.Date(2025, 11, 15, 1, 0, 0, 0, time.UTC) }
+	a := uuidifytest.NewServer(uuidifytest.WithSeed(42), uuidifytest.WithClock(now))
+	defer a.Close()
+	b := uuidifytest.NewServer(uuidifytest.WithSeed(42), uuidifytest.WithClock(now))
+	defer b.Close()
+
+	x, err := newClient(t, a).UUIDBatch(context.Background(), "v7", 3)
+	if err != nil {
+		t.Fatalf("UUIDBatch returned error: %v", err)
+	}
+	y, err := newClient(t, b).UUIDBatch(context.Background(), "v7", 3)
+	if err != nil {
+		t.Fatalf("UUIDBatch returned error: %v", err)
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			t.Fatalf("expected identical sequences, got %v and %v", x, y)
+		}
+	}
+}
+
+func newClient(t *testing.T, s *uuidifytest.Server) *uuidify.Client {
+	t.Helper()
+	c, err := s.NewClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return c
+}